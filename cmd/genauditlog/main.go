@@ -0,0 +1,794 @@
+// Command genauditlog generates the GraphQL audit log query fragments, the Node struct, a typed event struct for each audit entry type
+// and the table of event messages in pkg/github/auditlog_gen.go from the checked-in subset of the GitHub GraphQL schema and the
+// declarative spec of the actions the auditor alerts on. It is run by go generate in pkg/github, and with -check fails if the generated
+// file is out of date.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// auditEntryUnion is the schema union whose member types are the audit entries returned by an organisation's audit log.
+const auditEntryUnion = "OrganizationAuditEntry"
+
+// baseInterfaces are the interfaces every audit entry type implements. Their fields are held in the generated AuditEntry struct that
+// each typed event struct embeds.
+var baseInterfaces = []string{"AuditEntry", "Node", "OrganizationAuditEntryData"}
+
+type (
+
+	// spec is the declarative description of what is fetched from the audit log and how each action is described.
+	spec struct {
+		Fields    []string                     `json:"fields"`    // Fields fetched for every audit entry type that has them, in Node order.
+		Aliases   map[string]map[string]string `json:"aliases"`   // Fields fetched under a different name, by type.
+		Fragments map[string]string            `json:"fragments"` // Fragments used to select object fields, by object type.
+		Derived   []string                     `json:"derived"`   // Message arguments computed from a Node by the function of the same name.
+		Targets   map[string]string            `json:"targets"`   // Fields that can be the target of an action, with the noun for them.
+		Actions   []action                     `json:"actions"`
+	}
+
+	// action describes the alert created for an audit entry type. Placeholders such as {actor} or {visibility|lower} in the message
+	// are replaced by the named field or derived argument, optionally passed through a filter.
+	action struct {
+		Type     string   `json:"type"`
+		Action   string   `json:"action"`
+		Category string   `json:"category"`
+		Severity string   `json:"severity"`
+		Message  string   `json:"message"`
+		Requires []string `json:"requires"` // Fields that must be non-empty for the event to be alerted on.
+		Target   string   `json:"target"`   // The field or derived argument the action is performed on, if not the first in the message.
+	}
+
+	// goField is a field of the generated Node struct.
+	goField struct {
+		Name string
+		Type string
+		Tag  string
+		JSON string
+	}
+
+	// fragment is an inline fragment of the generated query, selecting fields on a type.
+	fragment struct {
+		Type       string
+		Selections []string
+	}
+
+	// eventType is a generated typed event struct, and its entry in the generated table of event messages.
+	eventType struct {
+		Name     string
+		Action   string
+		Fields   []goField // The fields other than those of the embedded AuditEntry.
+		Message  string
+		Category string
+		Severity string
+		Args     []string // Go expressions for the message's arguments.
+		Requires []string // Go names of the string fields that must be non-empty.
+		Target   string   // Go expression for the target.
+		Scopes   []scope  // The fields naming the team or repository the action was performed in, most specific first.
+	}
+
+	// scope is a field naming the team or repository an action was performed in, with the noun for it.
+	scope struct {
+		Name string
+		Noun string
+	}
+
+	// output is the data the generated file is rendered from.
+	output struct {
+		Sources   string
+		Imports   []string
+		Fragments []fragment
+		Fields    []goField
+		Base      []goField
+		Types     []eventType
+		Events    []eventType
+	}
+)
+
+var (
+	placeholderPattern = regexp.MustCompile(`\{(\w+)(?:\|(\w+))?\}`)
+
+	categories = map[string]string{
+		"oauth": "CategoryOAuth",
+		"org":   "CategoryOrg",
+		"repo":  "CategoryRepo",
+		"team":  "CategoryTeam",
+	}
+
+	severities = map[string]string{
+		"info":     "Info",
+		"warning":  "Warning",
+		"critical": "Critical",
+	}
+
+	filters = map[string]bool{
+		"lower": true,
+	}
+
+	// scopeFields are the fields naming the team or repository an action was performed in, most specific first. Actions on neither
+	// are performed in the event's organisation.
+	scopeFields = []string{"teamName", "repositoryName"}
+)
+
+func main() {
+	schemaFile := flag.String("schema", "schema/auditlog.graphql", "GraphQL schema definition language file")
+	specFile := flag.String("spec", "schema/actions.json", "JSON action spec file")
+	outputFile := flag.String("output", "auditlog_gen.go", "generated Go file")
+	check := flag.Bool("check", false, "fail if the generated file is out of date rather than writing it")
+	flag.Parse()
+	log.SetFlags(0)
+
+	generated, err := generate(*schemaFile, *specFile)
+	if err != nil {
+		log.Fatalf("Failed to generate %s: %v", *outputFile, err)
+	}
+
+	if *check {
+		existing, err := ioutil.ReadFile(*outputFile)
+		if err != nil || !bytes.Equal(existing, generated) {
+			log.Fatalf("%s is out of date: run go generate ./...", *outputFile)
+		}
+
+		return
+	}
+
+	if err := ioutil.WriteFile(*outputFile, generated, 0644); err != nil {
+		log.Fatalf("Failed to write %s: %v", *outputFile, err)
+	}
+}
+
+func generate(schemaFile, specFile string) ([]byte, error) {
+	sdl, err := ioutil.ReadFile(schemaFile)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := parseSchema(string(sdl))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %v", err)
+	}
+
+	specJSON, err := ioutil.ReadFile(specFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var sp spec
+	if err := json.Unmarshal(specJSON, &sp); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %v", err)
+	}
+
+	if _, ok := s.unions[auditEntryUnion]; !ok {
+		return nil, fmt.Errorf("schema doesn't contain the %s union", auditEntryUnion)
+	}
+
+	fields, err := nodeFields(s, sp)
+	if err != nil {
+		return nil, err
+	}
+
+	fragments, err := queryFragments(s, sp)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := baseFields(s, sp, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := eventTypes(s, sp, fields, base)
+	if err != nil {
+		return nil, err
+	}
+
+	types := append([]eventType(nil), events...)
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name < types[j].Name
+	})
+
+	imports := []string{"encoding/json", "fmt"}
+	for _, e := range events {
+		if strings.Contains(strings.Join(e.Args, " "), "strings.") {
+			imports = append(imports, "strings")
+			break
+		}
+	}
+
+	var buf bytes.Buffer
+	err = fileTemplate.Execute(&buf, output{
+		Sources:   fmt.Sprintf("%s and %s", schemaFile, specFile),
+		Imports:   imports,
+		Fragments: fragments,
+		Fields:    fields,
+		Base:      base,
+		Types:     types,
+		Events:    events,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// fieldsOf returns the fields of the passed audit entry type that are fetched, keyed by their name in the response.
+func fieldsOf(s *schema, sp spec, typeName string) map[string]fieldType {
+	fields := make(map[string]fieldType)
+	t := s.types[typeName]
+
+	for _, name := range sp.Fields {
+		if ft, ok := t.fields[name]; ok {
+			fields[name] = ft
+		}
+	}
+
+	for name, alias := range sp.Aliases[typeName] {
+		if ft, ok := t.fields[name]; ok {
+			fields[alias] = ft
+		}
+	}
+
+	return fields
+}
+
+// nodeFields returns the fields of the Node struct, checking that each fetched field has the same Go type on every audit entry type.
+func nodeFields(s *schema, sp spec) ([]goField, error) {
+	goTypes := make(map[string]string)
+	nonNull := make(map[string]bool)
+
+	for _, member := range s.unions[auditEntryUnion] {
+		for name, ft := range fieldsOf(s, sp, member) {
+			goType, err := goTypeOf(s, sp, ft)
+			if err != nil {
+				return nil, fmt.Errorf("field %s.%s: %v", member, name, err)
+			}
+
+			if existing, ok := goTypes[name]; ok && existing != goType {
+				return nil, fmt.Errorf("field %s has Go type %s on %s but %s elsewhere", name, goType, member, existing)
+			}
+
+			if _, ok := goTypes[name]; !ok {
+				nonNull[name] = ft.nonNull
+			}
+
+			goTypes[name] = goType
+			nonNull[name] = nonNull[name] && ft.nonNull
+		}
+	}
+
+	var fields []goField
+	for _, name := range sp.Fields {
+		goType, ok := goTypes[name]
+		if !ok {
+			return nil, fmt.Errorf("field %s isn't present on any audit entry type", name)
+		}
+
+		// Actors keep their Go field name as their JSON name, and fields every audit entry has are always included in the JSON.
+		tag := ""
+		switch {
+		case goType == "Actor":
+		case nonNull[name]:
+			tag = fmt.Sprintf("`json:\"%s\"`", name)
+		default:
+			tag = fmt.Sprintf("`json:\"%s,omitempty\"`", name)
+		}
+
+		fields = append(fields, goField{Name: goName(name), Type: goType, Tag: tag, JSON: name})
+	}
+
+	return fields, nil
+}
+
+// goTypeOf returns the Go type used for a field of the passed GraphQL type.
+func goTypeOf(s *schema, sp spec, ft fieldType) (string, error) {
+	switch {
+	case s.isObject(ft.name):
+		if ft.list {
+			return "", fmt.Errorf("lists of %s aren't supported", ft.name)
+		}
+
+		if _, ok := sp.Fragments[ft.name]; !ok {
+			return "", fmt.Errorf("no fragment for type %s", ft.name)
+		}
+
+		return "Actor", nil
+
+	case ft.list:
+		return "[]string", nil
+
+	case ft.name == "Boolean":
+		return "*bool", nil
+
+	case ft.name == "Int":
+		return "*int", nil
+	}
+
+	return "string", nil
+}
+
+// queryFragments returns the inline fragments selecting the fetched fields. Fields declared by an interface are selected once on the
+// interface rather than on every type implementing it, leaving only the fields particular to a type to be selected on the type.
+func queryFragments(s *schema, sp spec) ([]fragment, error) {
+	members := append([]string(nil), s.unions[auditEntryUnion]...)
+	sort.Strings(members)
+
+	interfaces := make(map[string]bool)
+	for _, member := range members {
+		t, ok := s.types[member]
+		if !ok {
+			return nil, fmt.Errorf("union member %s isn't defined", member)
+		}
+
+		for _, name := range t.implements {
+			interfaces[name] = true
+		}
+	}
+
+	var interfaceNames []string
+	for name := range interfaces {
+		interfaceNames = append(interfaceNames, name)
+	}
+
+	sort.Strings(interfaceNames)
+
+	var fragments []fragment
+	selected := make(map[string]bool)
+
+	for _, name := range interfaceNames {
+		iface := s.types[name]
+		if iface == nil {
+			return nil, fmt.Errorf("interface %s isn't defined", name)
+		}
+
+		var selections []string
+		for _, field := range sp.Fields {
+			if ft, ok := iface.fields[field]; ok {
+				selections = append(selections, selection(sp, field, field, ft))
+				selected[name+"."+field] = true
+			}
+		}
+
+		if len(selections) > 0 {
+			fragments = append(fragments, fragment{Type: name, Selections: selections})
+		}
+	}
+
+	for _, member := range members {
+		t := s.types[member]
+		aliases := make(map[string]string)
+		for field, alias := range sp.Aliases[member] {
+			aliases[alias] = field
+		}
+
+		var responseNames []string
+		for name := range fieldsOf(s, sp, member) {
+			responseNames = append(responseNames, name)
+		}
+
+		sort.Strings(responseNames)
+
+		var selections []string
+		for _, responseName := range responseNames {
+			field, aliased := aliases[responseName]
+			if !aliased {
+				field = responseName
+				if selectedByInterface(t, field, selected) {
+					continue
+				}
+			}
+
+			selections = append(selections, selection(sp, responseName, field, t.fields[field]))
+		}
+
+		if len(selections) > 0 {
+			fragments = append(fragments, fragment{Type: member, Selections: selections})
+		}
+	}
+
+	return fragments, nil
+}
+
+func selectedByInterface(t *objectType, field string, selected map[string]bool) bool {
+	for _, name := range t.implements {
+		if selected[name+"."+field] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selection returns the selection of the passed field under the passed response name, using the spec's fragment for object fields.
+func selection(sp spec, responseName, field string, ft fieldType) string {
+	sel := field
+	if responseName != field {
+		sel = responseName + ": " + field
+	}
+
+	if fragment, ok := sp.Fragments[ft.name]; ok {
+		sel += " {\n\t..." + fragment + "\n}"
+	}
+
+	return sel
+}
+
+// baseFields returns the fields of the AuditEntry struct embedded in every typed event struct, which are the fetched fields of the base
+// interfaces, checking that every audit entry type implements them.
+func baseFields(s *schema, sp spec, fields []goField) ([]goField, error) {
+	for _, member := range s.unions[auditEntryUnion] {
+		implements := make(map[string]bool)
+		for _, name := range s.types[member].implements {
+			implements[name] = true
+		}
+
+		for _, name := range baseInterfaces {
+			if !implements[name] {
+				return nil, fmt.Errorf("audit entry type %s doesn't implement %s", member, name)
+			}
+		}
+	}
+
+	var base []goField
+	for _, f := range fields {
+		for _, name := range baseInterfaces {
+			iface := s.types[name]
+			if iface == nil {
+				return nil, fmt.Errorf("interface %s isn't defined", name)
+			}
+
+			if _, ok := iface.fields[f.JSON]; ok {
+				base = append(base, f)
+				break
+			}
+		}
+	}
+
+	return base, nil
+}
+
+// eventTypes returns the typed event structs and their messages, checking every audit entry type has an action and every placeholder
+// can be filled. The returned types are sorted by action.
+func eventTypes(s *schema, sp spec, fields, base []goField) ([]eventType, error) {
+	derived := make(map[string]bool)
+	for _, name := range sp.Derived {
+		derived[name] = true
+	}
+
+	goFields := make(map[string]goField)
+	for _, f := range fields {
+		goFields[f.JSON] = f
+	}
+
+	inBase := make(map[string]bool)
+	for _, f := range base {
+		inBase[f.JSON] = true
+	}
+
+	described := make(map[string]bool)
+	seen := make(map[string]bool)
+	var events []eventType
+
+	for _, a := range sp.Actions {
+		if seen[a.Action] {
+			return nil, fmt.Errorf("action %s is described more than once", a.Action)
+		}
+
+		seen[a.Action] = true
+
+		if _, ok := s.types[a.Type]; !ok {
+			return nil, fmt.Errorf("action %s has unknown type '%s'", a.Action, a.Type)
+		}
+
+		if described[a.Type] {
+			return nil, fmt.Errorf("type %s has more than one action", a.Type)
+		}
+
+		described[a.Type] = true
+		typeFields := fieldsOf(s, sp, a.Type)
+
+		e := eventType{
+			Name:     a.Type,
+			Action:   a.Action,
+			Message:  strings.Replace(a.Message, "%", "%%", -1),
+			Category: categories[a.Category],
+		}
+
+		if len(e.Category) == 0 {
+			e.Category = fmt.Sprintf("Category(%q)", a.Category)
+		}
+
+		var ok bool
+		if e.Severity, ok = severities[a.Severity]; !ok {
+			return nil, fmt.Errorf("action %s has unknown severity %s", a.Action, a.Severity)
+		}
+
+		for _, name := range sp.Fields {
+			if _, ok := typeFields[name]; ok && !inBase[name] {
+				e.Fields = append(e.Fields, goFields[name])
+			}
+		}
+
+		for _, name := range scopeFields {
+			if _, ok := typeFields[name]; ok && !inBase[name] {
+				e.Scopes = append(e.Scopes, scope{Name: goFields[name].Name, Noun: sp.Targets[name]})
+			}
+		}
+
+		var targets []string
+		for _, match := range placeholderPattern.FindAllStringSubmatch(a.Message, -1) {
+			name, filter := match[1], match[2]
+			_, isField := typeFields[name]
+			if !isField && !derived[name] {
+				return nil, fmt.Errorf("action %s message refers to %s, which isn't a field of %s or a derived argument", a.Action, name, a.Type)
+			}
+
+			if len(filter) > 0 && !filters[filter] {
+				return nil, fmt.Errorf("action %s message uses unknown filter %s", a.Action, filter)
+			}
+
+			// Actors are capitalised when they start the message.
+			capitalise := len(e.Args) == 0 && strings.HasPrefix(a.Message, "{")
+			arg, err := argExpr(name, goFields[name], isField, capitalise)
+			if err != nil {
+				return nil, fmt.Errorf("action %s message: %v", a.Action, err)
+			}
+
+			if filter == "lower" {
+				arg = "strings.ToLower(" + arg + ")"
+			}
+
+			e.Args = append(e.Args, arg)
+			if name != "actor" && isField && (goFields[name].Type == "Actor" || len(sp.Targets[name]) > 0) {
+				targets = append(targets, name)
+			}
+		}
+
+		e.Message = placeholderPattern.ReplaceAllString(e.Message, "%s")
+
+		for _, name := range a.Requires {
+			if _, ok := typeFields[name]; !ok || goFields[name].Type != "string" {
+				return nil, fmt.Errorf("action %s requires %s, which isn't a string field of %s", a.Action, name, a.Type)
+			}
+
+			e.Requires = append(e.Requires, goFields[name].Name)
+		}
+
+		target := a.Target
+		if len(target) == 0 && len(targets) > 0 {
+			target = targets[0]
+		}
+
+		_, isField := typeFields[target]
+		switch {
+		case len(target) == 0:
+			return nil, fmt.Errorf("action %s has no target", a.Action)
+		case derived[target]:
+			e.Target = target + "(e.Node(), false)"
+		case isField && goFields[target].Type == "Actor":
+			e.Target = "e." + goFields[target].Name + ".Describe(false)"
+		case isField && len(sp.Targets[target]) > 0:
+			e.Target = fmt.Sprintf(`fmt.Sprintf("%s *%%s*", e.%s)`, sp.Targets[target], goFields[target].Name)
+		default:
+			return nil, fmt.Errorf("action %s has target %s, which isn't an actor, target field or derived argument", a.Action, target)
+		}
+
+		events = append(events, e)
+	}
+
+	for _, member := range s.unions[auditEntryUnion] {
+		if !described[member] {
+			return nil, fmt.Errorf("audit entry type %s has no action", member)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Action < events[j].Action
+	})
+
+	return events, nil
+}
+
+// argExpr returns the Go expression for a message argument, which is either the passed field or the derived argument with the passed
+// name.
+func argExpr(name string, f goField, isField, capitalise bool) (string, error) {
+	if !isField {
+		return fmt.Sprintf("%s(e.Node(), %t)", name, capitalise), nil
+	}
+
+	switch f.Type {
+	case "Actor":
+		return fmt.Sprintf("e.%s.Describe(%t)", f.Name, capitalise), nil
+	case "string":
+		return "e." + f.Name, nil
+	case "[]string":
+		return fmt.Sprintf(`strings.Join(e.%s, ", ")`, f.Name), nil
+	}
+
+	return "", fmt.Errorf("fields of type %s such as %s can't be used in messages", f.Type, name)
+}
+
+// goName returns the exported Go name for the passed GraphQL field name.
+func goName(name string) string {
+	if name == "id" {
+		return "ID"
+	}
+
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// indent indents each line of the passed text by the passed number of tabs.
+func indent(tabs int, text string) string {
+	prefix := strings.Repeat("\t", tabs)
+	return prefix + strings.Replace(text, "\n", "\n"+prefix, -1)
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(template.FuncMap{"indent": indent}).Parse(
+	`// Code generated by genauditlog from {{.Sources}}. DO NOT EDIT.
+
+package github
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+// auditEntrySelection selects the type and fields of every type of audit entry in an organisation's audit log.
+const auditEntrySelection = ` + "`" + `
+						__typename
+{{- range .Fragments}}
+						... on {{.Type}} {
+{{- range .Selections}}
+{{indent 7 .}}
+{{- end}}
+						}
+{{- end}}
+` + "`" + `
+
+type (
+
+	// Node represents a node in the returned results graph. Fields that don't exist on the node's type of audit entry are left empty.
+	Node struct {
+{{- range .Fields}}
+		{{.Name}} {{.Type}} {{.Tag}}
+{{- end}}
+		Raw json.RawMessage ` + "`" + `json:"-"` + "`" + ` // The JSON object GitHub sent for the event, with every field it included.
+	}
+
+	// AuditEntry holds the fields every type of audit entry has. It is embedded in each typed event.
+	AuditEntry struct {
+{{- range .Base}}
+		{{.Name}} {{.Type}} {{.Tag}}
+{{- end}}
+		Raw json.RawMessage ` + "`" + `json:"-"` + "`" + ` // The JSON object GitHub sent for the event, with every field it included.
+	}
+{{- range .Types}}
+
+	// {{.Name}} is a {{.Action}} event.
+	{{.Name}} struct {
+		AuditEntry
+{{- range .Fields}}
+		{{.Name}} {{.Type}} {{.Tag}}
+{{- end}}
+	}
+{{- end}}
+)
+
+// newAuditEvent returns a typed event for the passed GraphQL type name holding only the passed raw JSON, which is then decoded into
+// it, or nil if the type isn't known.
+func newAuditEvent(typename string, raw json.RawMessage) AuditEvent {
+	switch typename {
+{{- range .Types}}
+	case "{{.Name}}":
+		return &{{.Name}}{AuditEntry: AuditEntry{Raw: raw}}
+{{- end}}
+	}
+
+	return nil
+}
+
+// eventForNode returns the typed event for the action of the passed node, which has been normalised from the REST audit log or a
+// webhook. Unknown actions return an event of unknown type.
+func eventForNode(n Node) AuditEvent {
+	switch n.Action {
+{{- range .Events}}
+	case {{printf "%q" .Action}}:
+		return &{{.Name}}{
+			AuditEntry: auditEntryOf(n),
+{{- range .Fields}}
+			{{.Name}}: n.{{.Name}},
+{{- end}}
+		}
+{{- end}}
+	}
+
+	return &unknownEvent{n}
+}
+
+// auditEntryOf returns the fields of the passed node that every type of audit entry has.
+func auditEntryOf(n Node) AuditEntry {
+	return AuditEntry{
+{{- range .Base}}
+		{{.Name}}: n.{{.Name}},
+{{- end}}
+		Raw: n.Raw,
+	}
+}
+
+func (e AuditEntry) node() Node {
+	return Node{
+{{- range .Base}}
+		{{.Name}}: e.{{.Name}},
+{{- end}}
+		Raw: e.Raw,
+	}
+}
+{{- range .Types}}
+
+func (e *{{.Name}}) Node() Node {
+	n := e.AuditEntry.node()
+{{- range .Fields}}
+	n.{{.Name}} = e.{{.Name}}
+{{- end}}
+	return n
+}
+
+func (e *{{.Name}}) Target() string {
+	return {{.Target}}
+}
+
+{{- if .Scopes}}
+
+func (e *{{.Name}}) Scope() string {
+{{- range .Scopes}}
+	if len(e.{{.Name}}) > 0 {
+		return fmt.Sprintf("{{.Noun}} *%s*", e.{{.Name}})
+	}
+{{end}}
+	return e.AuditEntry.Scope()
+}
+{{- end}}
+
+func (e *{{.Name}}) Describe() string {
+{{- range .Requires}}
+	if len(e.{{.}}) == 0 {
+		return ""
+	}
+{{- end}}
+{{- if .Requires}}
+{{end}}
+	return fmt.Sprintf({{printf "%q" .Message}}{{range .Args}}, {{.}}{{end}})
+}
+
+func (e *{{.Name}}) Category() Category {
+	return {{.Category}}
+}
+
+func (e *{{.Name}}) Severity() Severity {
+	return {{.Severity}}
+}
+{{- end}}
+
+// eventInfo maps each GitHub action to its description string with format specifiers, category and severity.
+var eventInfo = map[string]EventInfo{
+{{- range .Events}}
+	{{printf "%q" .Action}}: {
+		Message:  {{printf "%q" .Message}},
+		Category: {{.Category}},
+		Severity: {{.Severity}},
+	},
+{{- end}}
+}
+`))
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testSchema is a minimal schema with a single audit entry type, including the description and directive syntax the parser skips.
+const testSchema = `
+"""An actor."""
+interface Actor {
+  login: String!
+}
+
+interface AuditEntry {
+  action: String!
+  actor: AuditEntryActor
+  createdAt: PreciseDateTime!
+}
+
+interface Node {
+  id: ID!
+}
+
+interface OrganizationAuditEntryData {
+  organizationName: String
+}
+
+scalar PreciseDateTime
+
+union AuditEntryActor = User
+
+union OrganizationAuditEntry = RepoAccessAuditEntry
+
+type RepoAccessAuditEntry implements AuditEntry & Node & OrganizationAuditEntryData {
+  action: String!
+  actor: AuditEntryActor
+  createdAt: PreciseDateTime!
+  id: ID!
+  organizationName: String
+  "The repository."
+  repositoryName(qualified: Boolean = true): String @deprecated(reason: "test")
+  topics: [String!]!
+  visibility: RepoAccessAuditEntryVisibility
+}
+
+type User implements Actor & Node {
+  id: ID!
+  login: String!
+}
+
+enum RepoAccessAuditEntryVisibility {
+  INTERNAL
+  PRIVATE
+  PUBLIC
+}
+`
+
+func TestGenerateMatchesCheckedInFile(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The generated file names its sources by the paths passed, which go generate passes relative to pkg/github.
+	if err := os.Chdir("../../pkg/github"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defer os.Chdir(wd)
+
+	generated, err := generate("schema/auditlog.graphql", "schema/actions.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	existing, err := ioutil.ReadFile("auditlog_gen.go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(generated, existing) {
+		t.Error("pkg/github/auditlog_gen.go is out of date: run go generate ./...")
+	}
+}
+
+func TestParseSchema(t *testing.T) {
+	s, err := parseSchema(testSchema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := s.unions[auditEntryUnion]; !reflect.DeepEqual(got, []string{"RepoAccessAuditEntry"}) {
+		t.Errorf("union members = %v", got)
+	}
+
+	entry := s.types["RepoAccessAuditEntry"]
+	if entry == nil {
+		t.Fatal("RepoAccessAuditEntry wasn't parsed")
+	}
+
+	if want := []string{"AuditEntry", "Node", "OrganizationAuditEntryData"}; !reflect.DeepEqual(entry.implements, want) {
+		t.Errorf("implements = %v, want %v", entry.implements, want)
+	}
+
+	fields := map[string]fieldType{
+		"action":         {name: "String", nonNull: true},
+		"repositoryName": {name: "String"},
+		"topics":         {name: "String", list: true, nonNull: true},
+		"visibility":     {name: "RepoAccessAuditEntryVisibility"},
+	}
+
+	for name, want := range fields {
+		if got := entry.fields[name]; got != want {
+			t.Errorf("field %s = %+v, want %+v", name, got, want)
+		}
+	}
+
+	if !s.isObject("User") || s.isObject("RepoAccessAuditEntryVisibility") {
+		t.Error("only User should be an object type")
+	}
+}
+
+func TestEventTypesPlaceholders(t *testing.T) {
+	tests := []struct {
+		name        string
+		message     string
+		wantMessage string
+		wantArgs    []string
+		wantTarget  string
+		wantErr     string
+	}{
+		{
+			name:        "fields and filters",
+			message:     "{actor} made repo *{repositoryName}* {visibility|lower}.",
+			wantMessage: "%s made repo *%s* %s.",
+			wantArgs:    []string{"e.Actor.Describe(true)", "e.RepositoryName", "strings.ToLower(e.Visibility)"},
+			wantTarget:  `fmt.Sprintf("repo *%s*", e.RepositoryName)`,
+		},
+		{
+			name:        "actor later in the message",
+			message:     "Repo *{repositoryName}* was changed by {actor}, 100% {topics}.",
+			wantMessage: "Repo *%s* was changed by %s, 100%% %s.",
+			wantArgs:    []string{"e.RepositoryName", "e.Actor.Describe(false)", `strings.Join(e.Topics, ", ")`},
+			wantTarget:  `fmt.Sprintf("repo *%s*", e.RepositoryName)`,
+		},
+		{
+			name:    "unknown placeholder",
+			message: "{actor} changed {teamName}.",
+			wantErr: "refers to teamName",
+		},
+		{
+			name:    "unknown filter",
+			message: "{actor} made repo *{repositoryName}* {visibility|upper}.",
+			wantErr: "unknown filter upper",
+		},
+		{
+			name:    "no target",
+			message: "{actor} changed something.",
+			wantErr: "has no target",
+		},
+	}
+
+	s, err := parseSchema(testSchema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, test := range tests {
+		sp := spec{
+			Fields:    []string{"id", "action", "actor", "createdAt", "organizationName", "repositoryName", "topics", "visibility"},
+			Fragments: map[string]string{"AuditEntryActor": "actorFields"},
+			Targets:   map[string]string{"repositoryName": "repo"},
+			Actions: []action{{
+				Type:     "RepoAccessAuditEntry",
+				Action:   "repo.access",
+				Category: "repo",
+				Severity: "info",
+				Message:  test.message,
+			}},
+		}
+
+		fields, err := nodeFields(s, sp)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		base, err := baseFields(s, sp, fields)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		events, err := eventTypes(s, sp, fields, base)
+		if len(test.wantErr) > 0 {
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("%s: error = %v, want one containing %q", test.name, err, test.wantErr)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		e := events[0]
+		if e.Message != test.wantMessage {
+			t.Errorf("%s: message = %q, want %q", test.name, e.Message, test.wantMessage)
+		}
+
+		if !reflect.DeepEqual(e.Args, test.wantArgs) {
+			t.Errorf("%s: args = %q, want %q", test.name, e.Args, test.wantArgs)
+		}
+
+		if e.Target != test.wantTarget {
+			t.Errorf("%s: target = %s, want %s", test.name, e.Target, test.wantTarget)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type (
+
+	// schema is the part of a GraphQL schema the generator uses: the object types and interfaces with their fields, and the unions.
+	schema struct {
+		types  map[string]*objectType
+		unions map[string][]string
+	}
+
+	// objectType is an object type or interface.
+	objectType struct {
+		name       string
+		implements []string
+		fields     map[string]fieldType
+	}
+
+	// fieldType is the type of a field, e.g. "[String!]!".
+	fieldType struct {
+		name    string
+		list    bool
+		nonNull bool
+	}
+
+	// parser parses GraphQL schema definition language (SDL). Descriptions, arguments and directives are skipped.
+	parser struct {
+		tokens []string
+		pos    int
+	}
+)
+
+// parseSchema parses the passed schema definition language.
+func parseSchema(sdl string) (*schema, error) {
+	tokens, err := tokenise(sdl)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &schema{
+		types:  make(map[string]*objectType),
+		unions: make(map[string][]string),
+	}
+
+	p := &parser{tokens: tokens}
+	for !p.done() {
+		if err := p.definition(s); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// isObject returns whether the passed type name is an object type, interface or union rather than a scalar or enum.
+func (s *schema) isObject(name string) bool {
+	_, isType := s.types[name]
+	_, isUnion := s.unions[name]
+	return isType || isUnion
+}
+
+func (p *parser) definition(s *schema) error {
+	keyword := p.next()
+	switch keyword {
+	case "scalar":
+		p.next()
+		p.skipDirectives()
+
+	case "enum":
+		p.next()
+		p.skipDirectives()
+		if err := p.skipBlock(); err != nil {
+			return err
+		}
+
+	case "union":
+		name := p.next()
+		p.skipDirectives()
+		if err := p.expect("="); err != nil {
+			return err
+		}
+
+		p.accept("|")
+		members := []string{p.next()}
+		for p.accept("|") {
+			members = append(members, p.next())
+		}
+
+		s.unions[name] = members
+
+	case "type", "interface":
+		t, err := p.objectType()
+		if err != nil {
+			return err
+		}
+
+		s.types[t.name] = t
+
+	default:
+		return fmt.Errorf("unsupported definition '%s'", keyword)
+	}
+
+	return nil
+}
+
+func (p *parser) objectType() (*objectType, error) {
+	t := &objectType{
+		name:   p.next(),
+		fields: make(map[string]fieldType),
+	}
+
+	if p.accept("implements") {
+		p.accept("&")
+		t.implements = append(t.implements, p.next())
+		for p.accept("&") {
+			t.implements = append(t.implements, p.next())
+		}
+	}
+
+	p.skipDirectives()
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	for !p.accept("}") {
+		if p.done() {
+			return nil, fmt.Errorf("unterminated type '%s'", t.name)
+		}
+
+		name := p.next()
+		if p.peek() == "(" {
+			if err := p.skipBlock(); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+
+		ft, err := p.fieldType()
+		if err != nil {
+			return nil, err
+		}
+
+		t.fields[name] = ft
+		p.skipDirectives()
+	}
+
+	return t, nil
+}
+
+func (p *parser) fieldType() (fieldType, error) {
+	var ft fieldType
+	if p.accept("[") {
+		inner, err := p.fieldType()
+		if err != nil {
+			return ft, err
+		}
+
+		if err := p.expect("]"); err != nil {
+			return ft, err
+		}
+
+		ft = fieldType{name: inner.name, list: true}
+	} else {
+		ft.name = p.next()
+	}
+
+	ft.nonNull = p.accept("!")
+	return ft, nil
+}
+
+// skipDirectives skips any directives, such as @deprecated(reason: "...").
+func (p *parser) skipDirectives() {
+	for p.accept("@") {
+		p.next()
+		if p.peek() == "(" {
+			p.skipBlock()
+		}
+	}
+}
+
+// skipBlock skips a block delimited by braces or parentheses, including any nested blocks.
+func (p *parser) skipBlock() error {
+	depth := 0
+	for !p.done() {
+		switch p.next() {
+		case "{", "(":
+			depth++
+		case "}", ")":
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		}
+	}
+
+	return errors.New("unterminated block")
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() string {
+	if p.done() {
+		return ""
+	}
+
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *parser) accept(token string) bool {
+	if p.peek() == token {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) expect(token string) error {
+	if actual := p.next(); actual != token {
+		return fmt.Errorf("expected '%s' but found '%s'", token, actual)
+	}
+
+	return nil
+}
+
+// tokenise splits the passed schema definition language into names and punctuators, dropping comments, descriptions and commas.
+func tokenise(sdl string) ([]string, error) {
+	var tokens []string
+
+	for i := 0; i < len(sdl); {
+		c := sdl[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == ',':
+			i++
+
+		case c == '#':
+			end := strings.IndexByte(sdl[i:], '\n')
+			if end < 0 {
+				end = len(sdl) - i
+			}
+
+			i += end
+
+		case strings.HasPrefix(sdl[i:], `"""`):
+			end := strings.Index(sdl[i+3:], `"""`)
+			if end < 0 {
+				return nil, errors.New("unterminated block string")
+			}
+
+			i += end + 6
+
+		case c == '"':
+			for i++; i < len(sdl) && sdl[i] != '"'; i++ {
+				if sdl[i] == '\\' {
+					i++
+				}
+			}
+
+			if i >= len(sdl) {
+				return nil, errors.New("unterminated string")
+			}
+
+			i++
+
+		case isNameChar(c):
+			start := i
+			for i < len(sdl) && isNameChar(sdl[i]) {
+				i++
+			}
+
+			tokens = append(tokens, sdl[start:i])
+
+		case strings.IndexByte("!$&():=@[]{|}", c) >= 0:
+			tokens = append(tokens, string(c))
+			i++
+
+		default:
+			return nil, fmt.Errorf("unexpected character '%c'", c)
+		}
+	}
+
+	return tokens, nil
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -1,54 +1,488 @@
 package main
 
 import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"flag"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/ONSdigital/github-auditor/internal/daemon"
 	"github.com/ONSdigital/github-auditor/internal/event"
+	"github.com/ONSdigital/github-auditor/internal/receiver"
+	"github.com/ONSdigital/github-auditor/internal/routing"
+	"github.com/ONSdigital/github-auditor/internal/rules"
+	"github.com/ONSdigital/github-auditor/pkg/email"
 	"github.com/ONSdigital/github-auditor/pkg/github"
+	firestore "github.com/ONSdigital/github-auditor/pkg/googlecloud"
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/opsgenie"
+	"github.com/ONSdigital/github-auditor/pkg/pagerduty"
+	"github.com/ONSdigital/github-auditor/pkg/slack"
+	"github.com/ONSdigital/github-auditor/pkg/state"
+	"github.com/ONSdigital/github-auditor/pkg/teams"
+	"github.com/ONSdigital/github-auditor/pkg/webhook"
+)
+
+const (
+	defaultStateFile     = "githubauditor-state.json"
+	defaultInterval      = 5 * time.Minute
+	defaultOverlap       = 15 * time.Minute
+	defaultJitter        = 30 * time.Second
+	defaultListenAddress = ":8080"
+	defaultMaxAttempts   = 10
+	shutdownTimeout      = 10 * time.Second
 )
 
 func main() {
-	firestoreCredentials := os.Getenv("FIRESTORE_CREDENTIALS")
+	backfill := flag.Bool("backfill", false, "Fetch the entire audit log rather than only the events newer than the saved high-water mark")
+	overlap := flag.Duration("overlap", defaultOverlap, "How far before the saved high-water mark to start fetching, to pick up events GitHub indexed late")
+	timeout := flag.Duration("timeout", 0, "Maximum duration of the run (or of each run in daemon mode), e.g. 30m (no limit if zero)")
+	digest := flag.Bool("digest", false, "Summarise alerts for the same action by the same actor in a single message (critical alerts are always sent individually)")
+	digestWindow := flag.Duration("digest-window", 0, "Only summarise alerts for events created within the same window of this duration, e.g. 1h (the whole run if zero)")
+	daemonMode := flag.Bool("daemon", false, "Keep running, polling the audit log every interval rather than exiting after a single run")
+	interval := flag.Duration("interval", defaultInterval, "Interval between the end of one run and the start of the next in daemon mode")
+	jitter := flag.Duration("jitter", defaultJitter, "Maximum random variation of the interval between runs in daemon mode")
+	maxAttempts := flag.Int("max-attempts", defaultMaxAttempts, "Number of runs an event that fails to be processed is retried by before it is given up on")
+	listen := flag.String("listen", defaultListenAddress, "Address the liveness (/healthz), readiness (/readyz) and webhook (/webhook) endpoints listen on in daemon mode")
+	flag.Parse()
 
-	firestoreProject := ""
-	if firestoreProject = os.Getenv("FIRESTORE_PROJECT"); len(firestoreProject) == 0 {
-		log.Fatal("Missing FIRESTORE_PROJECT environment variable")
+	// In daemon mode the timeout applies to each run rather than the process as a whole.
+	runTimeout := *timeout
+	if *daemonMode {
+		runTimeout = 0
 	}
 
-	token := ""
-	if token = os.Getenv("GITHUB_TOKEN"); len(token) == 0 {
-		log.Fatal("Missing GITHUB_TOKEN environmental variable")
+	ctx, cancel := newRunContext(runTimeout)
+	defer cancel()
+
+	source := os.Getenv("AUDIT_LOG_SOURCE")
+	switch source {
+	case "":
+		source = sourceGraphQL
+	case sourceGraphQL, sourceREST:
+	default:
+		log.Fatalf("Unknown AUDIT_LOG_SOURCE '%s' (expected graphql or rest)", source)
 	}
 
-	organisation := ""
-	if organisation = os.Getenv("GITHUB_ORG_NAME"); len(organisation) == 0 {
+	// The organisations of an enterprise are audited as well as any listed, so organisations aren't required if an enterprise is set.
+	enterprise := os.Getenv("GITHUB_ENTERPRISE_NAME")
+	organisations := splitList(os.Getenv("GITHUB_ORG_NAME"))
+	if len(organisations) == 0 && len(enterprise) == 0 {
 		log.Fatal("Missing GITHUB_ORG_NAME environmental variable")
 	}
 
-	slackAlertsChannel := ""
-	if slackAlertsChannel = os.Getenv("SLACK_ALERTS_CHANNEL"); len(slackAlertsChannel) == 0 {
-		log.Fatal("Missing SLACK_ALERTS_CHANNEL environment variable")
+	client, app, err := newGitHubClient()
+	if err != nil {
+		log.Fatalf("Failed to configure GitHub authentication: %v", err)
+	}
+
+	if client == nil && len(enterprise) > 0 {
+		log.Fatal("Missing GITHUB_APP_INSTALLATION_ID environment variable (required with GITHUB_ENTERPRISE_NAME when authenticating as a GitHub App)")
+	}
+
+	notifiers, err := newNotifiers()
+	if err != nil {
+		log.Fatalf("Failed to configure notifiers: %v", err)
+	}
+
+	// Messages are posted using the Slack Web API if a bot token is set, otherwise using an incoming webhook. Slack is only optional
+	// if another notifier is configured.
+	var transport slack.Transport
+	slackBotToken := os.Getenv("SLACK_BOT_TOKEN")
+	if len(slackBotToken) > 0 {
+		transport = slack.NewWebAPITransport(slackBotToken)
+	}
+
+	slackWebHookURL := os.Getenv("SLACK_WEBHOOK")
+	if len(slackWebHookURL) == 0 && transport == nil && len(notifiers) == 0 {
+		log.Fatal("Missing SLACK_WEBHOOK or SLACK_BOT_TOKEN environment variable")
+	}
+
+	var router *routing.Router
+	if len(slackWebHookURL) > 0 || transport != nil {
+		slackAlertsChannel := ""
+		if slackAlertsChannel = os.Getenv("SLACK_ALERTS_CHANNEL"); len(slackAlertsChannel) == 0 {
+			log.Fatal("Missing SLACK_ALERTS_CHANNEL environment variable")
+		}
+
+		defaultRoute := routing.Route{
+			Channel:         slackAlertsChannel,
+			WebHookURL:      slackWebHookURL,
+			CriticalMention: os.Getenv("SLACK_CRITICAL_MENTION"),
+		}
+
+		router = routing.NewRouter(defaultRoute)
+		if routesFile := os.Getenv("ROUTES_FILE"); len(routesFile) > 0 {
+			router, err = routing.Load(routesFile, defaultRoute)
+			if err != nil {
+				log.Fatalf("Failed to load alert routes: %v", err)
+			}
+		}
+	}
+
+	var ruleSet *rules.RuleSet
+	if rulesFile := os.Getenv("RULES_FILE"); len(rulesFile) > 0 {
+		ruleSet, err = rules.Load(rulesFile)
+		if err != nil {
+			log.Fatalf("Failed to load alert rules: %v", err)
+		}
+	}
+
+	store, err := newStateStore(ctx)
+	if err != nil {
+		log.Fatalf("Failed to open state store: %v", err)
+	}
+
+	defer store.Close()
+
+	// Events received by webhook don't have the IDs of their audit log entries, so when webhooks are received events are also
+	// correlated by their content to stop the audit log poll alerting on them again.
+	webhookSecret := os.Getenv("GITHUB_WEBHOOK_SECRET")
+	newProcessor := func() *event.Processor {
+		processor := event.NewProcessor(store, ruleSet, router, transport)
+		processor.SetWebURL(github.WebURL(os.Getenv("GITHUB_BASE_URL")))
+		processor.SetMaxAttempts(*maxAttempts)
+		for _, n := range notifiers {
+			processor.AddNotifier(n.name, n.notifier)
+		}
+
+		if len(webhookSecret) > 0 {
+			processor.EnableCorrelation()
+		}
+
+		return processor
+	}
+
+	processor := newProcessor()
+	if *digest {
+		processor.EnableDigest(*digestWindow)
+	}
+
+	a := &auditor{
+		organisations: organisations,
+		enterprise:    enterprise,
+		source:        source,
+		phrase:        os.Getenv("AUDIT_LOG_PHRASE"),
+		client:        client,
+		app:           app,
+		store:         store,
+		processor:     processor,
+		backfill:      *backfill,
+		overlap:       *overlap,
 	}
 
-	slackWebHookURL := ""
-	if slackWebHookURL = os.Getenv("SLACK_WEBHOOK"); len(slackWebHookURL) == 0 {
-		log.Fatal("Missing SLACK_WEBHOOK environment variable")
+	if *daemonMode {
+		var webhooks *receiver.Handler
+		if len(webhookSecret) > 0 {
+			webhooks = receiver.NewHandler(webhookSecret, newProcessor())
+		}
+
+		runDaemon(ctx, a, webhooks, *interval, *jitter, *timeout, *listen)
+		return
 	}
 
-	client := github.NewClient(token)
-	events, err := client.FetchAllAuditEvents(organisation)
+	result, err := a.run(ctx)
+	printResult(result)
+
 	if err != nil {
-		log.Fatalf("Failed to fetch audit log entries: %v", err)
+		log.Fatal(err)
+	}
+
+	if result.Failed > 0 || result.Undelivered > 0 || result.Abandoned > 0 {
+		os.Exit(1)
 	}
+}
+
+// runDaemon runs the passed auditor repeatedly until the passed context is cancelled, serving liveness and readiness endpoints and the
+// passed webhook handler (unless it is nil) on the passed address. Only the first run backfills the audit log if backfilling was requested.
+// Once cancelled, runDaemon returns when the current run and any webhook delivery being processed have finished.
+func runDaemon(ctx context.Context, a *auditor, webhooks *receiver.Handler, interval, jitter, timeout time.Duration, listen string) {
+	rand.Seed(time.Now().UnixNano())
+
+	scheduler := daemon.NewScheduler(interval, jitter, timeout, func(ctx context.Context) error {
+		result, err := a.run(ctx)
+		printResult(result)
+
+		if err == nil {
+			a.backfill = false
+		}
+
+		return err
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", scheduler.LivenessHandler())
+	mux.Handle("/readyz", scheduler.ReadinessHandler())
+	var processing sync.WaitGroup
+	if webhooks != nil {
+		mux.Handle("/webhook", webhooks)
+
+		processing.Add(1)
+		go func() {
+			defer processing.Done()
+			webhooks.Run(ctx)
+		}()
+	}
+
+	go func() {
+		if err := daemon.ListenAndServe(ctx, listen, mux, shutdownTimeout); err != nil {
+			log.Fatalf("Failed to serve HTTP endpoints: %v", err)
+		}
+	}()
 
 	// Using fmt rather than log so the output goes to STDOUT rather than STDERR.
-	fmt.Printf("Audit log API query returned %d results\n", len(events))
+	fmt.Printf("Polling the audit log every %v (±%v), serving HTTP endpoints on %s\n", interval, jitter, listen)
+
+	scheduler.Run(ctx)
+	processing.Wait()
+}
+
+// newGitHubClient returns the GitHub client configured using environment variables, authenticated as a GitHub App if GITHUB_APP_ID is
+// set or otherwise using the personal access token in GITHUB_TOKEN. When authenticating as an app without GITHUB_APP_INSTALLATION_ID
+// set, the returned client is nil and a client for the app's installation on each organisation is obtained from the returned app.
+func newGitHubClient() (*github.Client, *github.App, error) {
+	opts, err := newGitHubOptions()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	appID := os.Getenv("GITHUB_APP_ID")
+	if len(appID) == 0 {
+		token := ""
+		if token = os.Getenv("GITHUB_TOKEN"); len(token) == 0 {
+			return nil, nil, errors.New("missing GITHUB_TOKEN or GITHUB_APP_ID environment variable")
+		}
+
+		return github.NewClient(token, opts...), nil, nil
+	}
+
+	privateKey := []byte(os.Getenv("GITHUB_APP_PRIVATE_KEY"))
+	if privateKeyFile := os.Getenv("GITHUB_APP_PRIVATE_KEY_FILE"); len(privateKeyFile) > 0 {
+		if privateKey, err = ioutil.ReadFile(privateKeyFile); err != nil {
+			return nil, nil, fmt.Errorf("failed to read GitHub App private key: %v", err)
+		}
+	}
+
+	if len(privateKey) == 0 {
+		return nil, nil, errors.New("missing GITHUB_APP_PRIVATE_KEY or GITHUB_APP_PRIVATE_KEY_FILE environment variable")
+	}
+
+	app, err := github.NewApp(appID, privateKey, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	installationID := os.Getenv("GITHUB_APP_INSTALLATION_ID")
+	if len(installationID) == 0 {
+		return nil, app, nil
+	}
+
+	id, err := strconv.ParseInt(installationID, 10, 64)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid GITHUB_APP_INSTALLATION_ID '%s'", installationID)
+	}
+
+	return app.InstallationClient(id), app, nil
+}
+
+// newGitHubOptions returns the options for connecting to the GitHub API configured using the GITHUB_BASE_URL, GITHUB_PROXY,
+// GITHUB_CA_BUNDLE and GITHUB_TIMEOUT environment variables.
+func newGitHubOptions() ([]github.Option, error) {
+	var opts []github.Option
+
+	if baseURL := os.Getenv("GITHUB_BASE_URL"); len(baseURL) > 0 {
+		if _, err := url.ParseRequestURI(baseURL); err != nil {
+			return nil, fmt.Errorf("invalid GITHUB_BASE_URL '%s'", baseURL)
+		}
+
+		opts = append(opts, github.WithBaseURL(baseURL))
+	}
+
+	if proxy := os.Getenv("GITHUB_PROXY"); len(proxy) > 0 {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil || len(proxyURL.Host) == 0 {
+			return nil, fmt.Errorf("invalid GITHUB_PROXY '%s'", proxy)
+		}
+
+		opts = append(opts, github.WithProxy(proxyURL))
+	}
+
+	if caBundle := os.Getenv("GITHUB_CA_BUNDLE"); len(caBundle) > 0 {
+		pem, err := ioutil.ReadFile(caBundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GitHub CA bundle: %v", err)
+		}
+
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		if !rootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in GitHub CA bundle '%s'", caBundle)
+		}
+
+		opts = append(opts, github.WithRootCAs(rootCAs))
+	}
+
+	if timeout := os.Getenv("GITHUB_TIMEOUT"); len(timeout) > 0 {
+		d, err := time.ParseDuration(timeout)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid GITHUB_TIMEOUT '%s'", timeout)
+		}
+
+		opts = append(opts, github.WithTimeout(d))
+	}
+
+	return opts, nil
+}
+
+// newStateStore returns the state store selected by the STATE_STORE environment variable, defaulting to Firestore.
+func newStateStore(ctx context.Context) (state.Store, error) {
+	switch storeType := os.Getenv("STATE_STORE"); storeType {
+	case "", "firestore":
+		firestoreProject := ""
+		if firestoreProject = os.Getenv("FIRESTORE_PROJECT"); len(firestoreProject) == 0 {
+			return nil, errors.New("missing FIRESTORE_PROJECT environment variable")
+		}
+
+		var client *firestore.Client
+		var err error
+		if firestoreCredentials := os.Getenv("FIRESTORE_CREDENTIALS"); len(firestoreCredentials) > 0 {
+			client, err = firestore.NewClientWithCredentials(ctx, firestoreProject, firestoreCredentials)
+		} else {
+			client, err = firestore.NewClient(ctx, firestoreProject)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return client, nil
+
+	case "file":
+		stateFile := defaultStateFile
+		if s := os.Getenv("STATE_FILE"); len(s) > 0 {
+			stateFile = s
+		}
+
+		store, err := state.NewFileStore(stateFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return store, nil
 
-	if len(firestoreCredentials) > 0 {
-		event.ProcessWithCredentials(events, firestoreCredentials, firestoreProject, slackAlertsChannel, slackWebHookURL)
+	case "memory":
+		return state.NewMemoryStore(), nil
+
+	default:
+		return nil, fmt.Errorf("unknown STATE_STORE '%s' (expected firestore, file or memory)", storeType)
+	}
+}
+
+// namedNotifier is a notifier with the name its deliveries are recorded under in the state store.
+type namedNotifier struct {
+	name     string
+	notifier notify.Notifier
+}
+
+// newNotifiers returns the notifiers other than Slack that are configured using environment variables, including the incident
+// management services.
+func newNotifiers() ([]namedNotifier, error) {
+	var notifiers []namedNotifier
+
+	if teamsWebHookURL := os.Getenv("TEAMS_WEBHOOK"); len(teamsWebHookURL) > 0 {
+		notifiers = append(notifiers, namedNotifier{"teams", teams.NewNotifier(teamsWebHookURL)})
+	}
+
+	if smtpServer := os.Getenv("SMTP_SERVER"); len(smtpServer) > 0 {
+		recipients := splitList(os.Getenv("EMAIL_TO"))
+		n, err := email.NewNotifier(smtpServer, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), os.Getenv("EMAIL_FROM"), recipients)
+		if err != nil {
+			return nil, err
+		}
+
+		notifiers = append(notifiers, namedNotifier{"email", n})
+	}
+
+	if webHookURL := os.Getenv("WEBHOOK_URL"); len(webHookURL) > 0 {
+		var n *webhook.Notifier
+		var err error
+		if templateFile := os.Getenv("WEBHOOK_TEMPLATE"); len(templateFile) > 0 {
+			n, err = webhook.NewNotifierFromFile(webHookURL, templateFile)
+		} else {
+			n, err = webhook.NewNotifier(webHookURL, "")
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		notifiers = append(notifiers, namedNotifier{"webhook", n})
+	}
+
+	// Incidents are only raised for critical events.
+	if routingKey := os.Getenv("PAGERDUTY_ROUTING_KEY"); len(routingKey) > 0 {
+		notifiers = append(notifiers, namedNotifier{"pagerduty", notify.WithSeverities(pagerduty.NewNotifier(routingKey), string(github.Critical))})
+	}
+
+	if apiKey := os.Getenv("OPSGENIE_API_KEY"); len(apiKey) > 0 {
+		notifiers = append(notifiers, namedNotifier{"opsgenie", notify.WithSeverities(opsgenie.NewNotifier(os.Getenv("OPSGENIE_API_URL"), apiKey), string(github.Critical))})
+	}
+
+	return notifiers, nil
+}
+
+// splitList returns the non-empty items of the passed comma-separated list with surrounding whitespace removed.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// newRunContext returns a context that is cancelled when the process receives SIGINT or SIGTERM (as sent by Cloud Run and
+// Kubernetes when stopping a container), or when the passed timeout elapses if it is non-zero.
+func newRunContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
 	} else {
-		event.Process(events, firestoreProject, slackAlertsChannel, slackWebHookURL)
+		ctx, cancel = context.WithCancel(context.Background())
 	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case sig := <-signals:
+			log.Printf("Received %v, shutting down", sig)
+			cancel()
+		case <-ctx.Done():
+		}
+
+		signal.Stop(signals)
+	}()
+
+	return ctx, cancel
 }
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/github-auditor/internal/event"
+	"github.com/ONSdigital/github-auditor/pkg/github"
+	"github.com/ONSdigital/github-auditor/pkg/state"
+	"github.com/pkg/errors"
+)
+
+// Audit log sources, selected using the AUDIT_LOG_SOURCE environment variable.
+const (
+	sourceGraphQL = "graphql"
+	sourceREST    = "rest"
+)
+
+type (
+
+	// auditor fetches and processes the audit logs of one or more GitHub organisations, or of a GitHub enterprise when using the REST
+	// audit log. If an enterprise is set when using the GraphQL audit log, its organisations are discovered at the start of each run.
+	// The auditor's clients are reused across runs in daemon mode. When authenticating as a GitHub App without a fixed installation,
+	// client is nil and a client for the app's installation on each organisation is used instead.
+	auditor struct {
+		organisations []string
+		enterprise    string
+		source        string
+		phrase        string
+		client        *github.Client
+		app           *github.App
+		store         state.Store
+		processor     *event.Processor
+		backfill      bool
+		overlap       time.Duration
+	}
+
+	// target is a single audit log: an organisation's, or an enterprise's if enterprise is set.
+	target struct {
+		organisation string
+		enterprise   string
+	}
+)
+
+// run fetches and processes the audit log events created since the saved high-water mark of each of the auditor's targets, or their
+// entire audit logs if backfilling. A failure to process an individual event is recorded in the returned result; an error is only
+// returned if an audit log or the state store couldn't be read, and then only once every other target has been processed.
+func (a *auditor) run(ctx context.Context) (event.Result, error) {
+	var result event.Result
+
+	targets, err := a.targets(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	// Alerts are only threaded with other alerts from the same run.
+	a.processor.ResetThreads()
+
+	var errs []string
+	for _, t := range targets {
+		targetResult, err := a.runTarget(ctx, t)
+		result.Add(targetResult)
+
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", t, err))
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if len(errs) > 0 {
+		return result, errors.New(strings.Join(errs, "; "))
+	}
+
+	return result, nil
+}
+
+// runTarget fetches and processes the audit log events of the passed target created since its saved high-water mark, or its entire
+// audit log if backfilling.
+func (a *auditor) runTarget(ctx context.Context, t target) (event.Result, error) {
+	var result event.Result
+
+	highWaterMark := ""
+	if !a.backfill {
+		var err error
+		highWaterMark, err = a.store.HighWaterMark(ctx, t.stateKey())
+		if err != nil {
+			return result, errors.Wrap(err, "failed to retrieve audit log high-water mark")
+		}
+	}
+
+	// Each page is processed as soon as it arrives and the high-water mark advanced past it, so a failure part way through the audit log
+	// doesn't lose the work already done. Pages are fetched in ascending createdAt order so the last event in a page is the newest.
+	// Once an event has failed or its alert hasn't reached every sink, the high-water mark stops advancing so the next run retries it;
+	// events already processed are skipped. An event that keeps failing is eventually abandoned by the processor, which then no longer
+	// counts it as failed or undelivered so the mark advances.
+	// In digest mode alerts are held back until the processor is flushed, so the high-water mark is only advanced once they are sent.
+	newest := ""
+	processPage := func(events []github.AuditEvent) error {
+		pageResult, err := a.processor.Process(ctx, events)
+		result.Add(pageResult)
+		if err != nil {
+			return err
+		}
+
+		if len(events) > 0 && result.Failed == 0 && result.Undelivered == 0 {
+			newest = later(highWaterMark, events[len(events)-1].Node().CreatedAt)
+			if a.processor.Pending() == 0 {
+				return a.store.SaveHighWaterMark(ctx, t.stateKey(), newest)
+			}
+		}
+
+		return nil
+	}
+
+	client, err := a.clientFor(ctx, t)
+	if err != nil {
+		return result, err
+	}
+
+	// GitHub indexes audit log entries with a delay, so an entry can appear after entries created later than it have been processed.
+	// Fetching from before the high-water mark picks such entries up; those already processed are skipped using the state store.
+	since := highWaterMark
+	if len(highWaterMark) > 0 {
+		if since, err = lookback(highWaterMark, a.overlap); err != nil {
+			return result, err
+		}
+
+		fmt.Printf("Fetching audit log entries for %s created since %s\n", t, since)
+	} else {
+		fmt.Printf("Fetching all audit log entries for %s\n", t)
+	}
+
+	err = a.stream(ctx, client, t, since, processPage)
+
+	if a.processor.Pending() > 0 {
+		flushResult, flushErr := a.processor.Flush(ctx)
+		result.Add(flushResult)
+
+		if flushErr == nil && result.Failed == 0 && result.Undelivered == 0 && len(newest) > 0 {
+			flushErr = a.store.SaveHighWaterMark(ctx, t.stateKey(), newest)
+		}
+
+		if flushErr != nil {
+			log.Printf("Failed to flush digest alerts: %v", flushErr)
+			result.Failed++
+		}
+	}
+
+	return result, errors.Wrap(err, "failed to fetch audit log entries")
+}
+
+// targets returns the audit logs to process: the enterprise's when reading the REST audit log of an enterprise, otherwise those of the
+// auditor's organisations and of every organisation in its enterprise.
+func (a *auditor) targets(ctx context.Context) ([]target, error) {
+	if a.source == sourceREST && len(a.enterprise) > 0 {
+		return []target{{enterprise: a.enterprise}}, nil
+	}
+
+	organisations := a.organisations
+	if len(a.enterprise) > 0 {
+		discovered, err := a.client.EnterpriseOrganizations(ctx, a.enterprise)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to discover enterprise organisations")
+		}
+
+		organisations = append(append([]string(nil), organisations...), discovered...)
+	}
+
+	var targets []target
+	seen := make(map[string]bool)
+	for _, organisation := range organisations {
+		if !seen[organisation] {
+			seen[organisation] = true
+			targets = append(targets, target{organisation: organisation})
+		}
+	}
+
+	return targets, nil
+}
+
+// clientFor returns the client used to read the passed target's audit log.
+func (a *auditor) clientFor(ctx context.Context, t target) (*github.Client, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+
+	return a.app.OrganizationClient(ctx, t.organisation)
+}
+
+// stream calls the passed function with each page of events from the passed target's audit log created at or after the passed
+// high-water mark, or every event if it is empty.
+func (a *auditor) stream(ctx context.Context, client *github.Client, t target, highWaterMark string, fn github.PageFunc) error {
+	switch {
+	case a.source == sourceREST && len(t.enterprise) > 0:
+		return client.StreamEnterpriseRESTAuditEvents(ctx, t.enterprise, highWaterMark, a.phrase, fn)
+	case a.source == sourceREST:
+		return client.StreamOrganizationRESTAuditEvents(ctx, t.organisation, highWaterMark, a.phrase, fn)
+	case len(highWaterMark) > 0:
+		return client.StreamAuditEventsSince(ctx, t.organisation, highWaterMark, fn)
+	}
+
+	return client.StreamAllAuditEvents(ctx, t.organisation, fn)
+}
+
+// lookback returns the RFC 3339 timestamp the passed duration before the passed RFC 3339 high-water mark.
+func lookback(highWaterMark string, overlap time.Duration) (string, error) {
+	t, err := time.Parse(time.RFC3339, highWaterMark)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse high-water mark '%s'", highWaterMark)
+	}
+
+	return t.Add(-overlap).Format(time.RFC3339Nano), nil
+}
+
+// later returns the later of the passed RFC 3339 timestamps, so the events re-read from before the high-water mark don't move it back.
+func later(highWaterMark, createdAt string) string {
+	mark, err := time.Parse(time.RFC3339, highWaterMark)
+	if err != nil {
+		return createdAt
+	}
+
+	if t, err := time.Parse(time.RFC3339, createdAt); err == nil && t.Before(mark) {
+		return highWaterMark
+	}
+
+	return createdAt
+}
+
+// stateKey returns the key the high-water mark of the target's audit log is saved under: the organisation name, or the enterprise name
+// prefixed with "enterprise:" for an enterprise audit log.
+func (t target) stateKey() string {
+	if len(t.enterprise) > 0 {
+		return "enterprise:" + t.enterprise
+	}
+
+	return t.organisation
+}
+
+// String returns a description of the target for log messages.
+func (t target) String() string {
+	if len(t.enterprise) > 0 {
+		return "enterprise " + t.enterprise
+	}
+
+	return "organisation " + t.organisation
+}
+
+// printResult prints a summary of the passed result followed by the error for each failed, undelivered or abandoned event.
+func printResult(result event.Result) {
+
+	// Using fmt rather than log so the output goes to STDOUT rather than STDERR.
+	fmt.Printf("Processed %d audit log events: %d alerted, %d skipped, %d undelivered, %d failed, %d abandoned\n", result.Processed, result.Alerted, result.Skipped, result.Undelivered, result.Failed, result.Abandoned)
+
+	for _, e := range result.Errors {
+		log.Println(e)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/github-auditor/internal/event"
+	"github.com/ONSdigital/github-auditor/pkg/github"
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/state"
+)
+
+// rejecter fails to send alerts for one action and sends every other alert.
+type rejecter struct {
+	action string
+}
+
+func (r rejecter) Notify(ctx context.Context, alert notify.Alert) error {
+	if alert.Action == r.action {
+		return errors.New("rejected")
+	}
+
+	return nil
+}
+
+func TestEventThatAlwaysFailsIsAbandoned(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"_document_id": "1", "action": "repo.destroy", "actor": "octocat", "repo": "ons/app", "@timestamp": 1591012800000},
+			{"_document_id": "2", "action": "repo.create", "actor": "octocat", "repo": "ons/lib", "@timestamp": 1591012860000}
+		]`))
+	}))
+
+	defer server.Close()
+
+	// The event fails if its alert reaches no sink, and is undelivered if it reaches some but not all of them.
+	for _, undelivered := range []bool{false, true} {
+		store := state.NewMemoryStore()
+		processor := event.NewProcessor(store, nil, nil, nil)
+		processor.AddNotifier("test", rejecter{action: "repo.destroy"})
+		if undelivered {
+			processor.AddNotifier("other", rejecter{})
+		}
+
+		processor.SetMaxAttempts(3)
+
+		a := &auditor{
+			organisations: []string{"ons"},
+			source:        sourceREST,
+			client:        github.NewClient("token", github.WithBaseURL(server.URL)),
+			store:         store,
+			processor:     processor,
+			overlap:       defaultOverlap,
+		}
+
+		tests := []struct {
+			retried       int
+			abandoned     int
+			highWaterMark string
+		}{
+			{retried: 1},
+			{retried: 1},
+			{abandoned: 1, highWaterMark: "2020-06-01T12:01:00.000Z"},
+			{highWaterMark: "2020-06-01T12:01:00.000Z"},
+		}
+
+		for i, test := range tests {
+			result, err := a.run(context.Background())
+			if err != nil {
+				t.Fatalf("run %d: unexpected error: %v", i+1, err)
+			}
+
+			retried := result.Failed
+			if undelivered {
+				retried = result.Undelivered
+			}
+
+			if retried != test.retried || result.Failed+result.Undelivered != test.retried || result.Abandoned != test.abandoned {
+				t.Errorf("undelivered %t, run %d: %d failed, %d undelivered and %d abandoned, want %d retried and %d abandoned", undelivered, i+1,
+					result.Failed, result.Undelivered, result.Abandoned, test.retried, test.abandoned)
+			}
+
+			highWaterMark, _ := store.HighWaterMark(context.Background(), "ons")
+			if highWaterMark != test.highWaterMark {
+				t.Errorf("undelivered %t, run %d: high-water mark = %q, want %q", undelivered, i+1, highWaterMark, test.highWaterMark)
+			}
+		}
+	}
+}
@@ -5,13 +5,9 @@ go 1.14
 require (
 	cloud.google.com/go/firestore v1.1.1
 	github.com/ONSdigital/graphql v0.2.2
-	github.com/elazarl/goproxy v0.0.0-20191011121108-aa519ddbe484 // indirect
 	github.com/matryer/is v1.2.0 // indirect
-	github.com/parnurzeal/gorequest v0.2.16
 	github.com/pkg/errors v0.8.1
-	github.com/smartystreets/goconvey v1.6.4 // indirect
 	golang.org/x/net v0.0.0-20191209160850-c0dbc17a3553 // indirect
 	google.golang.org/api v0.14.0
 	google.golang.org/grpc v1.21.1
-	moul.io/http2curl v1.0.0 // indirect
 )
@@ -0,0 +1,191 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// livenessIntervals is the number of polling intervals that can pass without a run completing before the scheduler is reported as not live.
+const livenessIntervals = 3
+
+type (
+
+	// RunFunc performs a single run, returning an error if the run failed.
+	RunFunc func(ctx context.Context) error
+
+	// Scheduler calls a run function repeatedly, waiting for a polling interval with random jitter between the end of one run and the
+	// start of the next. Runs never overlap, and the scheduler's health is reported by its liveness and readiness handlers.
+	Scheduler struct {
+		interval time.Duration
+		jitter   time.Duration
+		timeout  time.Duration
+		run      RunFunc
+		running  int32
+
+		mutex        sync.RWMutex
+		started      time.Time
+		lastFinished time.Time
+		lastErr      error
+		stopping     bool
+	}
+)
+
+// NewScheduler instantiates a scheduler calling the passed run function every interval, varied by a random duration of up to the
+// passed jitter either way so replicas don't poll in lockstep. Each run is cancelled if it takes longer than the passed timeout, unless
+// it is zero.
+func NewScheduler(interval, jitter, timeout time.Duration, run RunFunc) *Scheduler {
+	return &Scheduler{
+		interval: interval,
+		jitter:   jitter,
+		timeout:  timeout,
+		run:      run,
+	}
+}
+
+// Run calls the scheduler's run function immediately and then after each polling interval until the passed context is cancelled.
+func (s *Scheduler) Run(ctx context.Context) error {
+	s.mutex.Lock()
+	s.started = time.Now()
+	s.stopping = false
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		s.stopping = true
+		s.mutex.Unlock()
+	}()
+
+	for {
+		if err := s.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Run failed: %v", err)
+		}
+
+		delay := s.nextDelay()
+
+		// Using fmt rather than log so the output goes to STDOUT rather than STDERR.
+		fmt.Printf("Next run in %v\n", delay.Round(time.Second))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// RunOnce calls the scheduler's run function and records its outcome, unless a run is already in progress.
+func (s *Scheduler) RunOnce(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
+		return fmt.Errorf("previous run is still in progress")
+	}
+
+	defer atomic.StoreInt32(&s.running, 0)
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	err := s.run(ctx)
+
+	s.mutex.Lock()
+	s.lastFinished = time.Now()
+	s.lastErr = err
+	s.mutex.Unlock()
+
+	return err
+}
+
+// LivenessHandler returns a handler responding 200 OK while runs keep completing, or 503 Service Unavailable if no run has completed
+// for several polling intervals (plus the run timeout), suggesting the scheduler is stuck and the process should be restarted.
+func (s *Scheduler) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.RLock()
+		last := s.lastFinished
+		if last.IsZero() {
+			last = s.started
+		}
+		s.mutex.RUnlock()
+
+		threshold := livenessIntervals*(s.interval+s.jitter) + s.timeout
+		if !last.IsZero() && time.Since(last) > threshold {
+			http.Error(w, fmt.Sprintf("no run has completed since %s", last.Format(time.RFC3339)), http.StatusServiceUnavailable)
+			return
+		}
+
+		fmt.Fprintln(w, "ok")
+	})
+}
+
+// ReadinessHandler returns a handler responding 200 OK while the scheduler is running and so the process can serve requests such as
+// webhook deliveries, or 503 Service Unavailable before it starts and once it is stopping. Whether runs succeed doesn't affect
+// readiness, as a failing poll of the audit log doesn't stop the process serving, but the error of the most recent run is included
+// in the response to help diagnose it.
+func (s *Scheduler) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.RLock()
+		started := s.started
+		stopping := s.stopping
+		lastErr := s.lastErr
+		s.mutex.RUnlock()
+
+		switch {
+		case started.IsZero():
+			http.Error(w, "not started", http.StatusServiceUnavailable)
+		case stopping:
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+		case lastErr != nil:
+			fmt.Fprintf(w, "ok (last run failed: %v)\n", lastErr)
+		default:
+			fmt.Fprintln(w, "ok")
+		}
+	})
+}
+
+// nextDelay returns the polling interval varied by a random duration of up to the jitter either way.
+func (s *Scheduler) nextDelay() time.Duration {
+	delay := s.interval
+	if s.jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(2*s.jitter))) - s.jitter
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+
+	return delay
+}
+
+// ListenAndServe serves the passed handler on the passed address until the passed context is cancelled, then shuts the server down
+// gracefully, waiting up to the passed timeout for in-flight requests to finish.
+func ListenAndServe(ctx context.Context, addr string, handler http.Handler, shutdownTimeout time.Duration) error {
+	server := &http.Server{
+		Addr:    addr,
+		Handler: handler,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	return server.Shutdown(shutdownCtx)
+}
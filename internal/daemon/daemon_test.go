@@ -0,0 +1,46 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func readiness(s *Scheduler) int {
+	w := httptest.NewRecorder()
+	s.ReadinessHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	return w.Code
+}
+
+func TestReadinessDoesNotDependOnRuns(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := make(chan struct{})
+	s := NewScheduler(time.Hour, 0, 0, func(ctx context.Context) error {
+		close(ran)
+		return errors.New("poll failed")
+	})
+
+	if got := readiness(s); got != http.StatusServiceUnavailable {
+		t.Errorf("before starting: status = %d, want %d", got, http.StatusServiceUnavailable)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(stopped)
+	}()
+
+	<-ran
+	if got := readiness(s); got != http.StatusOK {
+		t.Errorf("while running: status = %d, want %d", got, http.StatusOK)
+	}
+
+	cancel()
+	<-stopped
+	if got := readiness(s); got != http.StatusServiceUnavailable {
+		t.Errorf("after stopping: status = %d, want %d", got, http.StatusServiceUnavailable)
+	}
+}
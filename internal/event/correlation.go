@@ -0,0 +1,105 @@
+package event
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/github-auditor/pkg/github"
+	"github.com/ONSdigital/github-auditor/pkg/state"
+)
+
+// correlationWindow is the duration of the windows events are correlated within. An event received by webhook is correlated with the
+// same event fetched from the audit log if their timestamps fall in the same or adjacent windows.
+const correlationWindow = 10 * time.Minute
+
+// identifierPattern matches a name emphasised in message text, such as *alice*.
+var identifierPattern = regexp.MustCompile(`\*([^*]+)\*`)
+
+// correlationDocs returns the document saved to record that an alert was sent for the passed event, identified by the event's content
+// rather than its ID, and the documents whose existence shows an alert was already sent for the same event by another source. No
+// documents are returned if the event's timestamp can't be parsed.
+func correlationDocs(e github.AuditEvent) (state.Doc, []state.Doc) {
+	createdAt, err := time.Parse(time.RFC3339, e.Node().CreatedAt)
+	if err != nil {
+		return state.Doc{}, nil
+	}
+
+	window := createdAt.Truncate(correlationWindow)
+	save := correlationDoc(e, window)
+	check := []state.Doc{
+		correlationDoc(e, window.Add(-correlationWindow)),
+		save,
+		correlationDoc(e, window.Add(correlationWindow)),
+	}
+
+	return save, check
+}
+
+// correlationDoc returns the correlation document for the passed event in the window starting at the passed time. The document is
+// identified by the identifiers in the event's subject, target and scope, which both the audit log and webhooks give for the actions
+// mapped from webhooks, and the fields that tell apart different events of the same action on the same target, such as the permission
+// granted. Fields that only the audit log sets are only included for actions that aren't mapped from webhooks.
+func correlationDoc(event github.AuditEvent, window time.Time) state.Doc {
+	e := event.Node()
+	timestamp := window.UTC().Format(time.RFC3339)
+
+	// Webhooks don't give fields such as the previous permission or the reason for a change (for example a member being removed for not
+	// enabling two-factor authentication), so for actions mapped from webhooks they would stop the audit log event matching the webhook's.
+	auditLogOnly := func(value string) string {
+		if github.WebhookAction(e.Action) {
+			return ""
+		}
+
+		return value
+	}
+
+	// Webhooks only give the visibility of repos whose visibility was changed or that were created, but it's what tells apart making
+	// a repo public and making it private.
+	visibility := ""
+	if e.Action == "repo.access" {
+		visibility = e.Visibility
+	}
+
+	key := strings.ToLower(strings.Join([]string{
+		e.Action,
+		identifiers(event.Subject().Describe(false)),
+		identifiers(event.Target()),
+		identifiers(event.Scope()),
+		visibility,
+		e.Permission,
+		auditLogOnly(e.PermissionWas),
+		auditLogOnly(e.TopicName),
+		auditLogOnly(e.MergeType),
+		auditLogOnly(lastPathElement(e.ParentTeamName)),
+		auditLogOnly(e.Reason),
+		timestamp,
+	}, "|"))
+
+	sum := sha256.Sum256([]byte(key))
+	return state.Doc{
+		ID:        "correlation-" + hex.EncodeToString(sum[:]),
+		Timestamp: timestamp,
+		Action:    e.Action,
+	}
+}
+
+// identifiers returns the names emphasised in the passed message text, such as "alice" and "app" in "user *alice* (Alice)" and
+// "repo *ons/app*". The rest of the text is dropped, as webhooks don't give actors' names and some sources qualify names with their
+// organisation while others don't.
+func identifiers(text string) string {
+	var names []string
+	for _, match := range identifierPattern.FindAllStringSubmatch(text, -1) {
+		names = append(names, lastPathElement(match[1]))
+	}
+
+	return strings.Join(names, ",")
+}
+
+// lastPathElement returns the part of the passed name after its final slash, so that names qualified by their organisation (as the
+// audit log returns some repository and team names) match unqualified ones.
+func lastPathElement(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
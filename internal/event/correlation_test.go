@@ -0,0 +1,225 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/github-auditor/pkg/github"
+	"github.com/ONSdigital/github-auditor/pkg/state"
+)
+
+// uniqueStore is a state store that, like Firestore, rejects lookups of the same document more than once.
+type uniqueStore struct {
+	*state.MemoryStore
+}
+
+func (s uniqueStore) DocsExist(ctx context.Context, docs []state.Doc) ([]bool, error) {
+	seen := make(map[string]bool)
+	for _, doc := range docs {
+		if seen[doc.ID] {
+			return nil, fmt.Errorf("document %s requested more than once", doc.ID)
+		}
+
+		seen[doc.ID] = true
+	}
+
+	return s.MemoryStore.DocsExist(ctx, docs)
+}
+
+func webhookEvent(t *testing.T, eventType, deliveryID, payload string, receivedAt time.Time) github.AuditEvent {
+	events, err := github.WebhookEvents(eventType, deliveryID, []byte(payload), receivedAt)
+	if err != nil || len(events) != 1 {
+		t.Fatalf("WebhookEvents returned %d events, error %v", len(events), err)
+	}
+
+	return events[0]
+}
+
+func TestCorrelationMatchesWebhookWithAuditLog(t *testing.T) {
+	createdAt := time.Date(2020, 6, 1, 12, 1, 0, 0, time.UTC)
+	receivedAt := createdAt.Add(5 * time.Second)
+
+	tests := []struct {
+		name     string
+		webhook  github.AuditEvent
+		auditLog github.Node
+	}{
+		{
+			name: "repo.add_member",
+			webhook: webhookEvent(t, "member", "1", `{
+				"action": "added",
+				"sender": {"login": "alice", "type": "User"},
+				"organization": {"login": "ons"},
+				"repository": {"full_name": "ons/app", "private": true},
+				"member": {"login": "bob", "type": "User"}
+			}`, receivedAt),
+			auditLog: github.Node{
+				Action:         "repo.add_member",
+				Actor:          github.Actor{Type: "User", Login: "alice", Name: "Alice"},
+				User:           github.Actor{Type: "User", Login: "bob"},
+				RepositoryName: "ons/app",
+			},
+		},
+		{
+			name: "org.add_member",
+			webhook: webhookEvent(t, "organization", "2", `{
+				"action": "member_added",
+				"sender": {"login": "alice", "type": "User"},
+				"organization": {"login": "ons"},
+				"membership": {"user": {"login": "bob", "type": "User"}, "role": "admin"}
+			}`, receivedAt),
+			auditLog: github.Node{
+				Action:     "org.add_member",
+				Actor:      github.Actor{Type: "User", Login: "alice", Name: "Alice"},
+				User:       github.Actor{Type: "User", Login: "bob"},
+				Permission: "ADMIN",
+			},
+		},
+		{
+			name: "org.add_member with previous permission",
+			webhook: webhookEvent(t, "organization", "4", `{
+				"action": "member_added",
+				"sender": {"login": "alice", "type": "User"},
+				"organization": {"login": "ons"},
+				"membership": {"user": {"login": "bob", "type": "User"}, "role": "member"}
+			}`, receivedAt),
+			auditLog: github.Node{
+				Action:        "org.add_member",
+				Actor:         github.Actor{Type: "User", Login: "alice", Name: "Alice"},
+				User:          github.Actor{Type: "User", Login: "bob"},
+				Permission:    "READ",
+				PermissionWas: "NONE",
+			},
+		},
+		{
+			name: "org.remove_member with reason",
+			webhook: webhookEvent(t, "organization", "5", `{
+				"action": "member_removed",
+				"sender": {"login": "alice", "type": "User"},
+				"organization": {"login": "ons"},
+				"membership": {"user": {"login": "bob", "type": "User"}, "role": "member"}
+			}`, receivedAt),
+			auditLog: github.Node{
+				Action:        "org.remove_member",
+				Actor:         github.Actor{Type: "User", Login: "alice", Name: "Alice"},
+				User:          github.Actor{Type: "User", Login: "bob"},
+				PermissionWas: "READ",
+				Reason:        "TWO_FACTOR_REQUIREMENT_NON_COMPLIANCE",
+			},
+		},
+		{
+			name: "repo.access",
+			webhook: webhookEvent(t, "repository", "3", `{
+				"action": "publicized",
+				"sender": {"login": "alice", "type": "User"},
+				"organization": {"login": "ons"},
+				"repository": {"full_name": "ons/app"}
+			}`, receivedAt),
+			auditLog: github.Node{
+				Action:         "repo.access",
+				Actor:          github.Actor{Type: "User", Login: "alice", Name: "Alice"},
+				RepositoryName: "ons/app",
+				Visibility:     "PUBLIC",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test.auditLog.CreatedAt = createdAt.Format(time.RFC3339)
+		test.auditLog.OrganizationName = "ons"
+		webhookDoc, _ := correlationDocs(test.webhook)
+		_, checks := correlationDocs(github.EventForNode(test.auditLog))
+
+		matched := false
+		for _, check := range checks {
+			matched = matched || check == webhookDoc
+		}
+
+		if !matched {
+			t.Errorf("%s: audit log event doesn't correlate with webhook event", test.name)
+		}
+	}
+}
+
+func TestCorrelationTellsEventsApart(t *testing.T) {
+	createdAt := time.Date(2020, 6, 1, 12, 1, 0, 0, time.UTC).Format(time.RFC3339)
+	actor := github.Actor{Type: "User", Login: "alice"}
+
+	tests := []struct {
+		name string
+		a, b github.Node
+	}{
+		{
+			name: "permission",
+			a:    github.Node{Action: "org.update_member", Actor: actor, User: github.Actor{Login: "bob"}, Permission: "ADMIN", PermissionWas: "READ"},
+			b:    github.Node{Action: "org.update_member", Actor: actor, User: github.Actor{Login: "bob"}, Permission: "READ", PermissionWas: "ADMIN"},
+		},
+		{
+			name: "topic",
+			a:    github.Node{Action: "repo.add_topic", Actor: actor, RepositoryName: "ons/app", TopicName: "go"},
+			b:    github.Node{Action: "repo.add_topic", Actor: actor, RepositoryName: "ons/app", TopicName: "slack"},
+		},
+		{
+			name: "visibility",
+			a:    github.Node{Action: "repo.access", Actor: actor, RepositoryName: "ons/app", Visibility: "PUBLIC"},
+			b:    github.Node{Action: "repo.access", Actor: actor, RepositoryName: "ons/app", Visibility: "PRIVATE"},
+		},
+		{
+			name: "actor",
+			a:    github.Node{Action: "repo.destroy", Actor: actor, RepositoryName: "ons/app"},
+			b:    github.Node{Action: "repo.destroy", Actor: github.Actor{Type: "User", Login: "carol"}, RepositoryName: "ons/app"},
+		},
+		{
+			name: "user",
+			a:    github.Node{Action: "team.add_member", Actor: actor, TeamName: "ons/platform", User: github.Actor{Type: "User", Login: "bob"}},
+			b:    github.Node{Action: "team.add_member", Actor: actor, TeamName: "ons/platform", User: github.Actor{Type: "User", Login: "carol"}},
+		},
+		{
+			name: "team",
+			a:    github.Node{Action: "team.add_member", Actor: actor, TeamName: "ons/platform", User: github.Actor{Type: "User", Login: "bob"}},
+			b:    github.Node{Action: "team.add_member", Actor: actor, TeamName: "ons/security", User: github.Actor{Type: "User", Login: "bob"}},
+		},
+		{
+			name: "organisation",
+			a:    github.Node{Action: "org.create", Actor: actor, OrganizationName: "ons"},
+			b:    github.Node{Action: "org.create", Actor: actor, OrganizationName: "ons-sandbox"},
+		},
+	}
+
+	for _, test := range tests {
+		test.a.CreatedAt = createdAt
+		test.b.CreatedAt = createdAt
+		a, _ := correlationDocs(github.EventForNode(test.a))
+		b, _ := correlationDocs(github.EventForNode(test.b))
+		if a.ID == b.ID {
+			t.Errorf("events differing by %s have the same correlation document", test.name)
+		}
+	}
+}
+
+func TestProcessLooksUpSharedCorrelationDocsOnce(t *testing.T) {
+	store := uniqueStore{state.NewMemoryStore()}
+	p := NewProcessor(store, nil, nil, nil)
+	p.EnableCorrelation()
+
+	// GitHub can deliver the same webhook more than once, giving events with different IDs but the same correlation documents.
+	payload := `{
+		"action": "added",
+		"sender": {"login": "alice", "type": "User"},
+		"organization": {"login": "ons"},
+		"repository": {"full_name": "ons/app", "private": true},
+		"member": {"login": "bob", "type": "User"}
+	}`
+
+	receivedAt := time.Date(2020, 6, 1, 12, 1, 0, 0, time.UTC)
+	events := []github.AuditEvent{
+		webhookEvent(t, "member", "1", payload, receivedAt),
+		webhookEvent(t, "member", "2", payload, receivedAt.Add(time.Second)),
+	}
+
+	if _, err := p.Process(context.Background(), events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
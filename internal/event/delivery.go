@@ -0,0 +1,104 @@
+package event
+
+import (
+	"context"
+
+	"github.com/ONSdigital/github-auditor/pkg/github"
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/state"
+)
+
+// slackSink is the name Slack deliveries are recorded under.
+const slackSink = "slack"
+
+// sink is a destination other than Slack that alerts are sent to. When an alert fails to reach some sinks, its delivery to each of the
+// others is recorded in the state store under the sink's name so that it isn't sent to them again when it is retried.
+type sink struct {
+	name     string
+	notifier notify.Notifier
+}
+
+// sinkNames returns the names of the processor's sinks: Slack if alerts are posted to it, followed by each notifier.
+func (p *Processor) sinkNames() []string {
+	var names []string
+	if p.router != nil {
+		names = append(names, slackSink)
+	}
+
+	for _, s := range p.sinks {
+		names = append(names, s.name)
+	}
+
+	return names
+}
+
+// deliveries returns, for each of the passed event documents, the set of sinks the event's alert has already been delivered to.
+func (p *Processor) deliveries(ctx context.Context, docs []state.Doc) ([]map[string]bool, error) {
+	names := p.sinkNames()
+	delivered := make([]map[string]bool, len(docs))
+	for i := range delivered {
+		delivered[i] = make(map[string]bool)
+	}
+
+	var lookup []state.Doc
+	for _, doc := range docs {
+		for _, name := range names {
+			lookup = append(lookup, deliveryDoc(doc, name))
+		}
+	}
+
+	if len(lookup) == 0 {
+		return delivered, nil
+	}
+
+	found, err := p.docsExistOnce(ctx, lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range docs {
+		for j, name := range names {
+			if found[i*len(names)+j] {
+				delivered[i][name] = true
+			}
+		}
+	}
+
+	return delivered, nil
+}
+
+// recordDelivery records in the passed result the outcome of sending the alert for the passed events, which had already been delivered
+// to the passed number of sinks and has now been sent to the passed sinks, returning the documents to save and the events that failed.
+// Events whose alert has reached every sink are saved as processed, along with the passed documents to save for each event. Otherwise
+// the events fail, so they are retried by the next run. An alert that has reached some sinks but not others is undelivered rather than
+// failed, and its delivery to each of the sinks it was sent to is saved so the retry only sends it to the sinks that failed.
+func recordDelivery(result *Result, events []github.AuditEvent, docs []state.Doc, saves [][]state.Doc, reached int, sent []string, err error) ([]state.Doc, []failure) {
+	var processed []state.Doc
+	if err == nil {
+		result.Alerted += len(events)
+		for _, s := range saves {
+			processed = append(processed, s...)
+		}
+
+		return processed, nil
+	}
+
+	for _, doc := range docs {
+		for _, name := range sent {
+			processed = append(processed, deliveryDoc(doc, name))
+		}
+	}
+
+	failures := make([]failure, len(events))
+	for i, e := range events {
+		failures[i] = failure{event: e.Node(), doc: docs[i], saves: saves[i], err: err, undelivered: reached+len(sent) > 0}
+	}
+
+	return processed, failures
+}
+
+// deliveryDoc returns the document recording that the alert for the event with the passed document was delivered to the named sink.
+func deliveryDoc(doc state.Doc, sinkName string) state.Doc {
+	doc.ID = doc.ID + "-" + sinkName
+	return doc
+}
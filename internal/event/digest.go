@@ -0,0 +1,293 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/github-auditor/internal/routing"
+	"github.com/ONSdigital/github-auditor/pkg/github"
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/slack"
+	"github.com/ONSdigital/github-auditor/pkg/state"
+	"github.com/pkg/errors"
+)
+
+// maxDigestLines is the number of events listed in each digest message. Longer lists are split across thread replies when the Slack
+// transport supports threading, otherwise they are truncated.
+const maxDigestLines = 50
+
+type (
+
+	// digest holds alerts back so that alerts for the same action by the same actor can be summarised in a single message.
+	digest struct {
+		window time.Duration
+		groups map[string]*digestGroup
+		keys   []string // Group keys in the order the groups were created.
+	}
+
+	// digestGroup is a group of alerts for the same action by the same actor on the same team, repository or organisation.
+	digestGroup struct {
+		info       github.EventInfo
+		route      routing.Route
+		events     []github.AuditEvent
+		eventDocs  []state.Doc   // The document of each event, which its deliveries are recorded against.
+		saves      [][]state.Doc // The documents to save for each event once the group's alert has reached every sink.
+		texts      []string
+		timestamps []string
+	}
+)
+
+// EnableDigest switches the processor to digest mode. Alerts other than critical ones are held back and grouped by actor, action and
+// the team, repository or organisation acted on, and each group is posted as a single summary message when Flush is called. If the
+// passed window is non-zero, events are only grouped with other events created in the same window of that duration.
+func (p *Processor) EnableDigest(window time.Duration) {
+	p.digest = &digest{
+		window: window,
+		groups: make(map[string]*digestGroup),
+	}
+}
+
+// Pending returns the number of events held back in digest mode that haven't yet been posted by Flush.
+func (p *Processor) Pending() int {
+	if p.digest == nil {
+		return 0
+	}
+
+	pending := 0
+	for _, group := range p.digest.groups {
+		pending += len(group.events)
+	}
+
+	return pending
+}
+
+// Flush posts a message for each group of alerts held back in digest mode and saves their events to the state store. Groups of a single
+// alert are posted as normal alerts. As with Process, a failure to post a group is recorded in the returned result; an error is only
+// returned if the state store can't be read or written. A group is only skipped by the sinks every one of its events has already been
+// delivered to. Flush does nothing if the processor isn't in digest mode.
+func (p *Processor) Flush(ctx context.Context) (Result, error) {
+	var result Result
+	if p.digest == nil {
+		return result, nil
+	}
+
+	var eventDocs []state.Doc
+	for _, key := range p.digest.keys {
+		eventDocs = append(eventDocs, p.digest.groups[key].eventDocs...)
+	}
+
+	delivered, err := p.deliveries(ctx, eventDocs)
+	if err != nil {
+		return result, errors.Wrap(err, "failed to read documents from state store")
+	}
+
+	var processed []state.Doc
+	var failures []failure
+
+	for _, key := range p.digest.keys {
+		group := p.digest.groups[key]
+		skip, reached := groupDeliveries(delivered[:len(group.events)])
+		delivered = delivered[len(group.events):]
+
+		var sent []string
+		if len(group.events) == 1 {
+			sent, err = p.alert(ctx, group.events[0], group.info, group.route, group.timestamps[0], group.texts[0], skip)
+		} else {
+			sent, err = p.postDigest(ctx, group, skip)
+		}
+
+		done, failed := recordDelivery(&result, group.events, group.eventDocs, group.saves, reached, sent, err)
+		processed = append(processed, done...)
+		failures = append(failures, failed...)
+	}
+
+	p.digest.groups = make(map[string]*digestGroup)
+	p.digest.keys = nil
+
+	return result, p.finish(ctx, &result, processed, failures)
+}
+
+// postDigest posts a summary message for the passed group to Slack and sends it to each of the processor's notifiers, followed by the
+// list of its alerts, skipping the sinks in the passed set that it has already been delivered to. Every sink is tried even if an
+// earlier one fails. The names of the sinks the digest was sent to are returned along with the errors from any that failed.
+func (p *Processor) postDigest(ctx context.Context, group *digestGroup, delivered map[string]bool) ([]string, error) {
+	summary := formatDigestSummary(group)
+
+	lines := make([]string, len(group.texts))
+	for i, text := range group.texts {
+		lines[i] = formatDigestLine(group.events[i], group.timestamps[i], text)
+	}
+
+	var sent []string
+	var errs []error
+	if p.router != nil && !delivered[slackSink] {
+		if err := p.postSlackDigest(ctx, group, summary, lines); err != nil {
+			errs = append(errs, err)
+		} else {
+			sent = append(sent, slackSink)
+		}
+	}
+
+	if len(p.sinks) > 0 {
+		alert := newAlert(group.events[0], group.info, group.timestamps[0], formatDigestLines(summary, lines), p.webURL)
+		alert.Fields = digestFields(alert.Fields)
+		alert.Events = rawEvents(group.events...)
+		notified, notifyErrs := p.sendNotifications(ctx, alert, delivered)
+		sent = append(sent, notified...)
+		errs = append(errs, notifyErrs...)
+	}
+
+	return sent, combineErrors(errs)
+}
+
+// groupDeliveries returns the set of sinks every one of the passed deliveries of a group's events includes, which the group's alert
+// needn't be sent to again, and the number of sinks any of them includes.
+func groupDeliveries(delivered []map[string]bool) (map[string]bool, int) {
+	all := make(map[string]bool)
+	some := make(map[string]bool)
+	for name := range delivered[0] {
+		all[name] = true
+	}
+
+	for _, d := range delivered {
+		for name := range all {
+			if !d[name] {
+				delete(all, name)
+			}
+		}
+
+		for name := range d {
+			some[name] = true
+		}
+	}
+
+	return all, len(some)
+}
+
+// postSlackDigest posts a Slack summary message for the passed group followed by the passed list of its alerts, as thread replies if
+// the Slack transport supports threading or otherwise in the summary message's attachment.
+func (p *Processor) postSlackDigest(ctx context.Context, group *digestGroup, summary string, lines []string) error {
+	payload := newSlackPayload(group.events[0], group.info, group.timestamps[0], summary, group.route, p.webURL)
+	attachment := &payload.Attachments[0]
+
+	var fields []*slack.Field
+	for _, field := range attachment.Fields {
+		if isDigestField(field.Title) {
+			fields = append(fields, field)
+		}
+	}
+
+	attachment.Fields = fields
+
+	if p.transport == nil {
+		text := formatDigestLines(summary, lines)
+		attachment.Text = &text
+	}
+
+	ts, err := p.postSlackMessage(ctx, payload, group.route)
+	if err != nil {
+		return err
+	}
+
+	if len(ts) == 0 {
+		return nil
+	}
+
+	for start := 0; start < len(lines); start += maxDigestLines {
+		end := start + maxDigestLines
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		reply := slack.Payload{
+			Text:      strings.Join(lines[start:end], "\n"),
+			Username:  payload.Username,
+			Channel:   payload.Channel,
+			IconEmoji: payload.IconEmoji,
+			ThreadTS:  ts,
+		}
+
+		if _, err := p.postSlackMessage(ctx, reply, group.route); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *digest) add(e github.AuditEvent, info github.EventInfo, route routing.Route, doc state.Doc, text string, docs []state.Doc) {
+	// Events are grouped by scope rather than by target so that, for example, users added to the same team are summarised together.
+	key := strings.Join([]string{route.Channel, route.WebHookURL, subjectKey(e), e.Scope()}, "|")
+	if d.window > 0 {
+		if createdAt, err := time.Parse(time.RFC3339, e.Node().CreatedAt); err == nil {
+			key = fmt.Sprintf("%s|%d", key, createdAt.Truncate(d.window).Unix())
+		}
+	}
+
+	group, ok := d.groups[key]
+	if !ok {
+		group = &digestGroup{
+			info:  info,
+			route: route,
+		}
+
+		d.groups[key] = group
+		d.keys = append(d.keys, key)
+	}
+
+	group.events = append(group.events, e)
+	group.eventDocs = append(group.eventDocs, doc)
+	group.saves = append(group.saves, docs)
+	group.texts = append(group.texts, text)
+	group.timestamps = append(group.timestamps, doc.Timestamp)
+}
+
+// digestFields returns the passed fields without those that don't apply to a group of alerts as a whole.
+func digestFields(fields []notify.Field) []notify.Field {
+	var filtered []notify.Field
+	for _, field := range fields {
+		if isDigestField(field.Title) {
+			filtered = append(filtered, field)
+		}
+	}
+
+	return filtered
+}
+
+// isDigestField returns whether the field with the passed title applies to a group of alerts as a whole. The fields describing the
+// first event's target user and timestamp don't.
+func isDigestField(title string) bool {
+	return title != "User" && title != "Timestamp"
+}
+
+// formatDigestSummary returns the summary text for the passed group, e.g. "User *alice* performed 80 *team.add_member* actions on team *platform*".
+func formatDigestSummary(group *digestGroup) string {
+	e := group.events[0]
+	summary := fmt.Sprintf("%s performed %d *%s* actions", e.Subject().Describe(true), len(group.events), e.Node().Action)
+	if scope := e.Scope(); len(scope) > 0 {
+		summary = fmt.Sprintf("%s on %s", summary, scope)
+	}
+
+	return summary + "."
+}
+
+// formatDigestLine returns the line listing the passed event in a digest. As the digest's summary already gives the actor, action and
+// scope, the line only gives the event's target, unless the event has no target of its own in which case its full text is given.
+func formatDigestLine(e github.AuditEvent, timestamp, text string) string {
+	if target := e.Target(); len(target) > 0 && target != e.Scope() {
+		text = target
+	}
+
+	return fmt.Sprintf("• _%s_ %s", timestamp, text)
+}
+
+// formatDigestLines returns the passed summary followed by up to maxDigestLines of the passed lines.
+func formatDigestLines(summary string, lines []string) string {
+	if len(lines) <= maxDigestLines {
+		return summary + "\n" + strings.Join(lines, "\n")
+	}
+
+	return fmt.Sprintf("%s\n%s\n_…and %d more_", summary, strings.Join(lines[:maxDigestLines], "\n"), len(lines)-maxDigestLines)
+}
@@ -0,0 +1,213 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/github-auditor/pkg/github"
+	"github.com/ONSdigital/github-auditor/pkg/state"
+)
+
+// memberEvent returns an event for octocat adding the passed user to the passed team or repository with the passed action.
+func memberEvent(id, action, team, repository, login string) github.AuditEvent {
+	return github.EventForNode(github.Node{
+		ID:               id,
+		Action:           action,
+		Actor:            github.Actor{Type: "User", Login: "octocat"},
+		CreatedAt:        "2020-06-01T12:00:00Z",
+		OrganizationName: "ons",
+		TeamName:         team,
+		RepositoryName:   repository,
+		User:             github.Actor{Type: "User", Login: login},
+	})
+}
+
+func TestDigestGroupsEventsByScope(t *testing.T) {
+	r := &recorder{}
+	p := NewProcessor(state.NewMemoryStore(), nil, nil, nil)
+	p.AddNotifier("test", r)
+	p.EnableDigest(0)
+
+	events := []github.AuditEvent{
+		memberEvent("1", "team.add_member", "ons/platform", "", "alice"),
+		memberEvent("2", "repo.add_member", "", "ons/app", "bob"),
+		memberEvent("3", "team.add_member", "ons/security", "", "carol"),
+		memberEvent("4", "team.add_member", "ons/platform", "", "dave"),
+		memberEvent("5", "repo.add_member", "", "ons/app", "erin"),
+	}
+
+	if _, err := p.Process(context.Background(), events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pending := p.Pending(); pending != len(events) {
+		t.Errorf("%d events pending, want %d", pending, len(events))
+	}
+
+	result, err := p.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Alerted != len(events) {
+		t.Errorf("alerted %d events, want %d", result.Alerted, len(events))
+	}
+
+	// Groups of a single event are sent as a normal alert.
+	want := []string{
+		"User *octocat* performed 2 *team.add_member* actions on team *ons/platform*.",
+		"User *octocat* performed 2 *repo.add_member* actions on repo *ons/app*.",
+		"User *octocat* added user *carol* to team *ons/security*.",
+	}
+
+	var got []string
+	for _, alert := range r.alerts {
+		got = append(got, strings.Split(alert.Text, "\n")[0])
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("alerts begin %q, want %q", got, want)
+	}
+
+	if pending := p.Pending(); pending != 0 {
+		t.Errorf("%d events pending after flushing, want 0", pending)
+	}
+}
+
+func TestFormatDigestLinesTruncates(t *testing.T) {
+	for _, n := range []int{maxDigestLines, maxDigestLines + 10} {
+		lines := make([]string, n)
+		for i := range lines {
+			lines[i] = fmt.Sprintf("line %d", i+1)
+		}
+
+		got := strings.Split(formatDigestLines("summary", lines), "\n")
+		want := append([]string{"summary"}, lines[:maxDigestLines]...)
+		if n > maxDigestLines {
+			want = append(want, "_…and 10 more_")
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%d lines: got %d lines ending %q, want %d ending %q", n, len(got), got[len(got)-1], len(want), want[len(want)-1])
+		}
+	}
+}
+
+func TestGroupDeliveries(t *testing.T) {
+	tests := []struct {
+		name      string
+		delivered []map[string]bool
+		skip      map[string]bool
+		reached   int
+	}{
+		{
+			name:      "none delivered",
+			delivered: []map[string]bool{{}, {}},
+			skip:      map[string]bool{},
+		},
+		{
+			name:      "all delivered to one sink",
+			delivered: []map[string]bool{{"slack": true}, {"slack": true, "email": true}},
+			skip:      map[string]bool{"slack": true},
+			reached:   2,
+		},
+		{
+			name:      "some delivered",
+			delivered: []map[string]bool{{"slack": true}, {}},
+			skip:      map[string]bool{},
+			reached:   1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			skip, reached := groupDeliveries(test.delivered)
+			if !reflect.DeepEqual(skip, test.skip) || reached != test.reached {
+				t.Errorf("groupDeliveries() = %v, %d, want %v, %d", skip, reached, test.skip, test.reached)
+			}
+		})
+	}
+}
+
+func TestFlushSkipsSinksTheWholeGroupWasDeliveredTo(t *testing.T) {
+	timestamp, _ := formatTime("2020-06-01T12:00:00Z")
+	delivery := func(id, sink string) state.Doc {
+		return state.Doc{ID: id + "-" + sink, Timestamp: timestamp, Action: "team.add_member"}
+	}
+
+	tests := []struct {
+		name        string
+		delivered   []state.Doc
+		failing     bool
+		first       int
+		second      int
+		undelivered int
+	}{
+		{
+			name:   "no earlier deliveries",
+			first:  1,
+			second: 1,
+		},
+		{
+			name:      "every event delivered to a sink",
+			delivered: []state.Doc{delivery("1", "first"), delivery("2", "first")},
+			second:    1,
+		},
+		{
+			name:      "some events delivered to a sink",
+			delivered: []state.Doc{delivery("1", "first")},
+			first:     1,
+			second:    1,
+		},
+		{
+			name:        "remaining sink fails",
+			delivered:   []state.Doc{delivery("1", "first"), delivery("2", "first")},
+			failing:     true,
+			undelivered: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := state.NewMemoryStore()
+			store.SaveDocs(context.Background(), test.delivered)
+
+			first, second := &recorder{}, &recorder{}
+			p := NewProcessor(store, nil, nil, nil)
+			p.AddNotifier("first", first)
+			if test.failing {
+				p.AddNotifier("second", failer{})
+			} else {
+				p.AddNotifier("second", second)
+			}
+
+			p.EnableDigest(0)
+
+			events := []github.AuditEvent{
+				memberEvent("1", "team.add_member", "ons/platform", "", "alice"),
+				memberEvent("2", "team.add_member", "ons/platform", "", "bob"),
+			}
+
+			if _, err := p.Process(context.Background(), events); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			result, err := p.Flush(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(first.alerts) != test.first || len(second.alerts) != test.second {
+				t.Errorf("sent %d alerts to the first sink and %d to the second, want %d and %d", len(first.alerts), len(second.alerts),
+					test.first, test.second)
+			}
+
+			if result.Undelivered != test.undelivered || result.Alerted != len(events)-test.undelivered {
+				t.Errorf("%d alerted and %d undelivered, want %d undelivered", result.Alerted, result.Undelivered, test.undelivered)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package event
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ONSdigital/github-auditor/pkg/github"
+	"github.com/ONSdigital/github-auditor/pkg/state"
+	"github.com/pkg/errors"
+)
+
+// defaultMaxAttempts is the number of times an event is processed before it is given up on if it keeps failing.
+const defaultMaxAttempts = 10
+
+// failure is an event that failed to be processed, or whose alert didn't reach every sink.
+type failure struct {
+	event       github.Node
+	doc         state.Doc   // The event's document, which its attempts are recorded against.
+	saves       []state.Doc // The documents to save if the event is given up on.
+	err         error
+	undelivered bool // Whether the event's alert reached some sinks.
+}
+
+// SetMaxAttempts sets the number of times an event is processed before it is given up on if it keeps failing. Failed and undelivered
+// events are retried by the next run, which holds back the high-water mark, so an event that can never be processed (such as one with a
+// malformed payload, or whose alert a sink always rejects) is eventually saved as processed to let the high-water mark advance past it.
+// The default is 10.
+func (p *Processor) SetMaxAttempts(n int) {
+	p.maxAttempts = n
+}
+
+// recordFailures records the passed failures in the passed result and returns the documents to save for them. Each failed attempt to
+// process an event is recorded in the state store so that, once an event has failed the processor's maximum number of times, it is
+// recorded as abandoned rather than failed or undelivered and its documents are returned to be saved so it isn't retried again. Attempts aren't
+// counted if the passed context has been cancelled, as the failures are then caused by the cancellation rather than the events.
+func (p *Processor) recordFailures(ctx context.Context, result *Result, failures []failure) ([]state.Doc, error) {
+	if len(failures) == 0 {
+		return nil, nil
+	}
+
+	if ctx.Err() != nil {
+		result.failAll(failures)
+		return nil, nil
+	}
+
+	// An event's earlier attempts are recorded as a document per attempt, as documents can only be looked up by their exact contents.
+	previous := p.maxAttempts - 1
+	if previous < 0 {
+		previous = 0
+	}
+
+	var lookup []state.Doc
+	for _, f := range failures {
+		for n := 1; n <= previous; n++ {
+			lookup = append(lookup, attemptDoc(f.doc, n))
+		}
+	}
+
+	found := make([]bool, len(lookup))
+	if len(lookup) > 0 {
+		var err error
+		if found, err = p.docsExistOnce(ctx, lookup); err != nil {
+			result.failAll(failures)
+			return nil, err
+		}
+	}
+
+	var docs []state.Doc
+	for i, f := range failures {
+		attempts := 1
+		for _, exists := range found[i*previous : (i+1)*previous] {
+			if exists {
+				attempts++
+			}
+		}
+
+		if attempts >= p.maxAttempts {
+			result.abandon(f.event, attempts, f.err)
+			docs = append(docs, f.saves...)
+			continue
+		}
+
+		result.record(f)
+		docs = append(docs, attemptDoc(f.doc, attempts))
+	}
+
+	return docs, nil
+}
+
+// finish records the passed failures in the passed result and saves the passed documents of the events that were processed along with
+// those recording the failures.
+func (p *Processor) finish(ctx context.Context, result *Result, processed []state.Doc, failures []failure) error {
+	docs, err := p.recordFailures(ctx, result, failures)
+	if saveErr := p.saveDocs(ctx, append(processed, docs...)); saveErr != nil {
+		return saveErr
+	}
+
+	return errors.Wrap(err, "failed to read documents from state store")
+}
+
+// failAll records each of the passed failures in the result as a failed or undelivered event, without counting the attempt.
+func (r *Result) failAll(failures []failure) {
+	for _, f := range failures {
+		r.record(f)
+	}
+}
+
+// record records the passed failure in the result as a failed or undelivered event.
+func (r *Result) record(f failure) {
+	if f.undelivered {
+		r.undeliver(f.event, f.err)
+		return
+	}
+
+	r.fail(f.event, f.err)
+}
+
+// attemptDoc returns the document recording the passed numbered failed attempt to process the event with the passed document.
+func attemptDoc(doc state.Doc, attempt int) state.Doc {
+	doc.ID = fmt.Sprintf("%s-attempt-%d", doc.ID, attempt)
+	return doc
+}
@@ -0,0 +1,205 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/github-auditor/internal/routing"
+	"github.com/ONSdigital/github-auditor/pkg/github"
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/slack"
+)
+
+const (
+	auditLogLinkText = "View Audit Log"
+)
+
+// severityColours maps each severity to the colour of the bar down the side of the Slack message attachment.
+var severityColours = map[github.Severity]string{
+	github.Info:     "#439FE0",
+	github.Warning:  "warning",
+	github.Critical: "danger",
+}
+
+// newSlackPayload returns a Slack message for the passed event, rendered as an attachment coloured by severity with fields describing
+// the event and buttons linking back to GitHub. The plain text of the alert is used as the attachment's fallback for clients that
+// can't display attachments.
+func newSlackPayload(event github.AuditEvent, info github.EventInfo, timestamp, text string, route routing.Route, webURL string) slack.Payload {
+	e := event.Node()
+	fallback := fmt.Sprintf("_%s_\n%s", timestamp, text)
+	colour := severityColours[info.Severity]
+	footer := fmt.Sprintf("GitHub audit log • %s • %s", e.Action, info.Severity)
+	if organisation := organisationForEvent(e); len(organisation) > 0 {
+		footer = fmt.Sprintf("GitHub audit log • %s • %s • %s", organisation, e.Action, info.Severity)
+	}
+	markdownIn := []string{"text", "fields"}
+
+	attachment := slack.Attachment{
+		Fallback:   &fallback,
+		Color:      &colour,
+		Text:       &text,
+		Footer:     &footer,
+		MarkdownIn: &markdownIn,
+	}
+
+	if createdAt, err := time.Parse(time.RFC3339, e.CreatedAt); err == nil {
+		ts := createdAt.Unix()
+		attachment.Timestamp = &ts
+	}
+
+	for _, field := range eventFields(event, timestamp) {
+		attachment.AddField(slack.Field{
+			Title: field.Title,
+			Value: field.Value,
+			Short: true,
+		})
+	}
+
+	for _, link := range eventLinks(event, info, webURL) {
+		style := "default"
+		if link.Text == auditLogLinkText {
+			style = "primary"
+		}
+
+		attachment.AddAction(slack.Action{
+			Type:  "button",
+			Text:  link.Text,
+			URL:   link.URL,
+			Style: style,
+		})
+	}
+
+	return slack.Payload{
+		Text:        route.Mention(info.Severity),
+		Username:    "GitHub Auditor Bot",
+		Channel:     route.Channel,
+		IconEmoji:   ":github:",
+		Attachments: []slack.Attachment{attachment},
+	}
+}
+
+// newAlert returns an alert for the passed event to send using the processor's notifiers, with the same fields and links as the
+// Slack message.
+func newAlert(event github.AuditEvent, info github.EventInfo, timestamp, text, webURL string) notify.Alert {
+	e := event.Node()
+	return notify.Alert{
+		ID:           e.ID,
+		Action:       e.Action,
+		Category:     string(info.Category),
+		Severity:     string(info.Severity),
+		Organisation: organisationForEvent(e),
+		Text:         text,
+		CreatedAt:    e.CreatedAt,
+		Timestamp:    timestamp,
+		Fields:       eventFields(event, timestamp),
+		Links:        eventLinks(event, info, webURL),
+		Events:       rawEvents(event),
+	}
+}
+
+// eventFields returns the fields describing the passed event, omitting those with empty values.
+func eventFields(event github.AuditEvent, timestamp string) []notify.Field {
+	e := event.Node()
+	var fields []notify.Field
+	addField := func(title, value string) {
+		if len(value) > 0 {
+			fields = append(fields, notify.Field{Title: title, Value: value})
+		}
+	}
+
+	addField("Actor", event.Subject().Describe(true))
+	addField("User", formatActorOrEmail(e.User, e.Email))
+	addField("Repository", e.RepositoryName)
+	addField("Team", e.TeamName)
+	addField("Organisation", organisationForEvent(e))
+	addField("Enterprise", e.EnterpriseSlug)
+	addField("Timestamp", timestamp)
+
+	return fields
+}
+
+// eventLinks returns the links back to GitHub for the passed event: the actor's profile, the repository (unless it has been deleted)
+// and the organisation's audit log filtered to the event's action, on the GitHub instance at the passed URL.
+func eventLinks(event github.AuditEvent, info github.EventInfo, webURL string) []notify.Link {
+	var links []notify.Link
+	e := event.Node()
+	organisation := organisationForEvent(e)
+
+	if subject := event.Subject(); subject.Type == "User" && len(subject.Login) > 0 {
+		links = append(links, notify.Link{
+			Text: "View Actor",
+			URL:  fmt.Sprintf("%s/%s", webURL, subject.Login),
+		})
+	}
+
+	if len(e.RepositoryName) > 0 && info.Category == github.CategoryRepo && e.Action != "repo.destroy" {
+		links = append(links, notify.Link{
+			Text: "View Repository",
+			URL:  fmt.Sprintf("%s/%s", webURL, repositoryPath(e.RepositoryName, organisation)),
+		})
+	}
+
+	if len(organisation) > 0 {
+		links = append(links, notify.Link{
+			Text: auditLogLinkText,
+			URL:  fmt.Sprintf("%s/organizations/%s/settings/audit-log?q=%s", webURL, organisation, url.QueryEscape("action:"+e.Action)),
+		})
+	}
+
+	return links
+}
+
+// organisationForEvent returns the name of the organisation the passed event belongs to, taken from the owner of its repository if
+// the organisation name isn't present.
+func organisationForEvent(e github.Node) string {
+	if len(e.OrganizationName) > 0 {
+		return e.OrganizationName
+	}
+
+	if i := strings.Index(e.RepositoryName, "/"); i > 0 {
+		return e.RepositoryName[:i]
+	}
+
+	return ""
+}
+
+// repositoryPath returns the owner/name path of the passed repository, qualifying it with the passed organisation if necessary.
+func repositoryPath(repositoryName, organisation string) string {
+	if strings.Contains(repositoryName, "/") || len(organisation) == 0 {
+		return repositoryName
+	}
+
+	return fmt.Sprintf("%s/%s", organisation, repositoryName)
+}
+
+// rawEvents returns the JSON GitHub sent for each of the passed events that has it.
+func rawEvents(events ...github.AuditEvent) []json.RawMessage {
+	var raw []json.RawMessage
+	for _, e := range events {
+		if n := e.Node(); len(n.Raw) > 0 {
+			raw = append(raw, n.Raw)
+		}
+	}
+
+	return raw
+}
+
+// subjectKey returns the part of a grouping key identifying the passed event's subject and action, so that alerts for the same action by
+// the same actor can be threaded or summarised together.
+func subjectKey(e github.AuditEvent) string {
+	subject := e.Subject()
+	return strings.Join([]string{subject.Type, subject.Login, subject.Name, e.Node().Action}, "|")
+}
+
+// formatActorOrEmail returns the passed actor as message text, or the passed email address if it is present, as it is in place of the
+// user when someone without a GitHub account is invited.
+func formatActorOrEmail(actor github.Actor, email string) string {
+	if len(email) > 0 {
+		return fmt.Sprintf("*%s*", email)
+	}
+
+	return actor.Describe(true)
+}
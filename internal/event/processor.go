@@ -1,181 +1,354 @@
 package event
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
+	"github.com/ONSdigital/github-auditor/internal/routing"
+	"github.com/ONSdigital/github-auditor/internal/rules"
 	"github.com/ONSdigital/github-auditor/pkg/github"
-	firestore "github.com/ONSdigital/github-auditor/pkg/googlecloud"
+	"github.com/ONSdigital/github-auditor/pkg/notify"
 	"github.com/ONSdigital/github-auditor/pkg/slack"
+	"github.com/ONSdigital/github-auditor/pkg/state"
+	"github.com/pkg/errors"
 )
 
-const slackRateLimitPause = 5 * time.Second
+const cancelledSaveTimeout = 10 * time.Second
 
-// Process processes the passed slice of GitHub audit events, creating Slack alerts in the passed Slack channel for events of interest.
-func Process(events []github.Node, firestoreProject, slackAlertsChannel, slackWebHookURL string) {
-	process(events, nil, firestoreProject, slackAlertsChannel, slackWebHookURL)
+// pendingAlert is an alert to send for the pending event with the index.
+type pendingAlert struct {
+	index int
+	info  github.EventInfo
+	route routing.Route
+	text  string
 }
 
-// ProcessWithCredentials processes the passed slice of GitHub audit events, creating Slack alerts in the passed Slack channel for events of interest.
-func ProcessWithCredentials(events []github.Node, firestoreCredentials, firestoreProject, slackAlertsChannel, slackWebHookURL string) {
-	process(events, &firestoreCredentials, firestoreProject, slackAlertsChannel, slackWebHookURL)
+// Processor processes GitHub audit events, creating Slack alerts for events of interest and sending them to any other notifiers.
+type Processor struct {
+	store     state.Store
+	ruleSet   *rules.RuleSet
+	router    *routing.Router
+	transport slack.Transport
+	webhooks  map[string]*slack.WebhookTransport
+	threads   map[string]string
+	sinks     []sink
+	digest    *digest
+	correlate bool
+	webURL    string
+	unknown   map[string]bool // Unknown actions that have been logged.
+
+	maxAttempts int
 }
 
-func process(events []github.Node, firestoreCredentials *string, firestoreProject, slackAlertsChannel, slackWebHookURL string) {
-	for _, e := range events {
-		timestamp := formatTime(e.CreatedAt)
-		id := e.ID
-		action := e.Action
-		text := ""
-		jsonData, err := json.Marshal(e)
+// NewProcessor instantiates a new processor. The passed state store is used to ensure duplicate alerts aren't created, the passed
+// rule set decides which events are alerted on (every event is if it is nil) and the passed router chooses the Slack channel for each
+// alert. Alerts are posted using the passed Slack transport, or the incoming webhook of each alert's route if it is nil. If the passed
+// router is nil no Slack alerts are posted, so alerts are only sent to the notifiers added using AddNotifier.
+func NewProcessor(store state.Store, ruleSet *rules.RuleSet, router *routing.Router, transport slack.Transport) *Processor {
+	return &Processor{
+		store:     store,
+		ruleSet:   ruleSet,
+		router:    router,
+		transport: transport,
+		webhooks:  make(map[string]*slack.WebhookTransport),
+		threads:   make(map[string]string),
+		unknown:   make(map[string]bool),
+		webURL:    github.WebURL(""),
+
+		maxAttempts: defaultMaxAttempts,
+	}
+}
+
+// AddNotifier adds a notifier that every alert is sent to in addition to Slack. The passed name, such as "email", identifies the
+// notifier's deliveries in the state store so must be unique and shouldn't change between runs.
+func (p *Processor) AddNotifier(name string, n notify.Notifier) {
+	p.sinks = append(p.sinks, sink{name: name, notifier: n})
+}
+
+// SetWebURL sets the URL of the GitHub instance that alerts link back to, such as that of a GitHub Enterprise Server instance. The
+// default is https://github.com.
+func (p *Processor) SetWebURL(webURL string) {
+	p.webURL = webURL
+}
+
+// EnableCorrelation switches the processor to correlation mode, in which events are also recorded in the state store by their content
+// so that an event received from more than one source with different IDs, such as an organisation webhook and the audit log, is only
+// alerted on once. Events are correlated if their timestamps are within a few minutes of each other.
+func (p *Processor) EnableCorrelation() {
+	p.correlate = true
+}
+
+// ResetThreads forgets the Slack threads alerts have been posted in, so later alerts start new threads rather than replying to alerts
+// posted by an earlier run.
+func (p *Processor) ResetThreads() {
+	p.threads = make(map[string]string)
+}
+
+// Process processes the passed slice of GitHub audit events. Events are processed in slice order, so callers streaming the audit log
+// can pass each page as it arrives. When the Slack transport supports threading, alerts for the same action by the same actor are
+// posted as replies to the first such alert posted by the processor. A failure to process an individual event is recorded in the
+// returned result rather than stopping processing; an error is only returned if the state store can't be read from or written to.
+func (p *Processor) Process(ctx context.Context, events []github.AuditEvent) (Result, error) {
+	var result Result
+	var pending []github.AuditEvent
+	var nodes []github.Node
+	var docs []state.Doc
+	var failures []failure
+
+	for _, event := range events {
+		result.Processed++
+		e := event.Node()
+
+		timestamp, err := formatTime(e.CreatedAt)
 		if err != nil {
-			log.Fatalf("Failed marshalling event to JSON: %v", err)
+			doc := state.Doc{ID: e.ID, Timestamp: e.CreatedAt, Action: e.Action}
+			failures = append(failures, failure{event: e, doc: doc, saves: []state.Doc{doc}, err: err})
+			continue
+		}
+
+		pending = append(pending, event)
+		nodes = append(nodes, e)
+		docs = append(docs, state.Doc{
+			ID:        e.ID,
+			Timestamp: timestamp,
+			Action:    e.Action,
+		})
+	}
+
+	// Look up all the events in a single batched read rather than one read per event.
+	exists, saves, err := p.docsExist(ctx, pending, docs)
+	if err != nil {
+		result.failAll(failures)
+		return result, errors.Wrap(err, "failed to read documents from state store")
+	}
+
+	// Only events that were successfully processed are saved, so failed events are retried by the next run.
+	var processed []state.Doc
+	var alerts []pendingAlert
+
+	for i, event := range pending {
+		e := nodes[i]
+		text := event.Describe()
+		if exists[i] || len(text) == 0 || !p.allows(event) {
+			result.Skipped++
+			processed = append(processed, saves[i]...)
+			continue
 		}
 
-		switch e.Action {
-
-		// OAuth events.
-		case "oauth_application.create":
-			text = fmt.Sprintf(github.MessageForEvent(action), e.OauthApplicationName, e.OrganizationName, formatActor(e.Actor, false))
-
-		// Organisation events.
-		case "org.add_billing_manager":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), formatActor(e.User, false), e.OrganizationName)
-		case "org.add_member":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.User, true), e.OrganizationName)
-		case "org.block_user":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.BlockedUser, true), formatActor(e.Actor, false), e.OrganizationName)
-		case "org.create":
-			text = fmt.Sprintf(github.MessageForEvent(action), e.OrganizationName, formatActor(e.Actor, false))
-		case "org.disable_saml":
-			text = fmt.Sprintf(github.MessageForEvent(action), e.OrganizationName, formatActor(e.Actor, false))
-		case "org.disable_two_factor_requirement":
-			text = fmt.Sprintf(github.MessageForEvent(action), e.OrganizationName, formatActor(e.Actor, false))
-		case "org.enable_oauth_app_restrictions":
-			text = fmt.Sprintf(github.MessageForEvent(action), e.OrganizationName, formatActor(e.Actor, false))
-		case "org.enable_saml":
-			text = fmt.Sprintf(github.MessageForEvent(action), e.OrganizationName, formatActor(e.Actor, false))
-		case "org.enable_two_factor_requirement":
-			text = fmt.Sprintf(github.MessageForEvent(action), e.OrganizationName, formatActor(e.Actor, false))
-		case "org.invite_member":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), formatActorOrEmail(e.User, e.Email, false), e.OrganizationName)
-		case "org.oauth_app_access_approved":
-			text = fmt.Sprintf(github.MessageForEvent(action), e.OauthApplicationName, e.OrganizationName, formatActor(e.Actor, false))
-		case "org.oauth_app_access_denied":
-			text = fmt.Sprintf(github.MessageForEvent(action), e.OauthApplicationName, e.OrganizationName, formatActor(e.Actor, false))
-		case "org.oauth_app_access_requested":
-			text = fmt.Sprintf(github.MessageForEvent(action), e.OauthApplicationName, e.OrganizationName, formatActor(e.Actor, false))
-		case "org.remove_billing_manager":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), formatActor(e.User, false), e.OrganizationName)
-		case "org.remove_member":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), formatActor(e.User, false), e.OrganizationName)
-		case "org.remove_outside_collaborator":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), formatActor(e.User, false), e.OrganizationName)
-		case "org.restore_member":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), formatActor(e.User, false), e.OrganizationName)
-		case "org.update_member":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), formatActor(e.User, false), strings.ToLower(e.PermissionWas), strings.ToLower(e.Permission), e.OrganizationName)
-
-		// Repo events.
-		case "repo.access":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), e.RepositoryName, strings.ToLower(e.Visibility))
-		case "repo.add_member":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), formatActor(e.User, false), e.RepositoryName)
-		case "repo.add_topic":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), e.TopicName, e.RepositoryName)
-		case "repo.archived":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), e.RepositoryName)
-		case "repo.change_merge_setting":
-
-			// A repo.change_merge_setting event is fired with a null merge setting when a new repo is created, so only log explicit merge setting changes.
-			if len(e.MergeType) > 0 {
-				text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), e.RepositoryName, strings.ToLower(e.MergeType))
-			} else {
-				text = ""
+		// The JSON GitHub sent is logged where available so no field is lost, as the Node only holds the fields the auditor uses.
+		jsonData := []byte(e.Raw)
+		if len(jsonData) == 0 {
+			if jsonData, err = json.Marshal(e); err != nil {
+				failures = append(failures, failure{event: e, doc: docs[i], saves: saves[i], err: errors.Wrap(err, "failed marshalling event to JSON")})
+				continue
 			}
-		case "repo.create":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), e.RepositoryName, strings.ToLower(e.Visibility))
-		case "repo.destroy":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), e.RepositoryName)
-		case "repo.remove_member":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), formatActor(e.User, false), e.RepositoryName)
-
-		// Team events.
-		case "team.add_member":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), formatActor(e.User, false), e.TeamName)
-		case "team.add_repository":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), e.TeamName, e.RepositoryName)
-		case "team.change_parent_team":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), e.TeamName, e.ParentTeamName)
-		case "team.remove_member":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), formatActor(e.User, false), e.TeamName)
-		case "team.remove_repository":
-			text = fmt.Sprintf(github.MessageForEvent(action), formatActor(e.Actor, true), e.TeamName, e.RepositoryName)
-		default:
-
-			// Using fmt rather than log so the output goes to STDOUT rather than STDERR.
-			fmt.Printf("Unknown GitHub event: %s\n", action)
 		}
 
-		var client *firestore.Client
+		logJSON(jsonData)
 
-		if firestoreCredentials != nil {
-			client = firestore.NewClientWithCredentials(firestoreProject, *firestoreCredentials)
-		} else {
-			client = firestore.NewClient(firestoreProject)
+		info := github.EventInfo{Category: event.Category(), Severity: event.Severity()}
+		var route routing.Route
+		if p.router != nil {
+			route = p.router.Route(organisationForEvent(e), info.Category, info.Severity)
 		}
 
-		if !client.DocExists(id, timestamp, action) && len(text) > 0 {
-			logJSON(jsonData)
-			postSlackMessage(timestamp, text, slackAlertsChannel, slackWebHookURL)
+		// In digest mode, alerts other than critical ones are held back to be summarised when the processor is flushed.
+		if p.digest != nil && info.Severity != github.Critical {
+			p.digest.add(event, info, route, docs[i], text, saves[i])
+			continue
 		}
 
-		err = client.SaveDoc(id, timestamp, action)
-		if err != nil {
-			log.Fatalf("Failed to save document to Firestore: %v", err)
+		alerts = append(alerts, pendingAlert{index: i, info: info, route: route, text: text})
+	}
+
+	alertDocs := make([]state.Doc, len(alerts))
+	for k, a := range alerts {
+		alertDocs[k] = docs[a.index]
+	}
+
+	// An earlier attempt to alert on an event may have reached some of the sinks, which aren't sent the alert again.
+	delivered, err := p.deliveries(ctx, alertDocs)
+	if err != nil {
+		result.failAll(failures)
+		return result, errors.Wrap(err, "failed to read documents from state store")
+	}
+
+	for k, a := range alerts {
+		event, doc := pending[a.index], docs[a.index]
+		sent, err := p.alert(ctx, event, a.info, a.route, doc.Timestamp, a.text, delivered[k])
+		done, failed := recordDelivery(&result, []github.AuditEvent{event}, []state.Doc{doc}, saves[a.index:a.index+1], len(delivered[k]), sent, err)
+		processed = append(processed, done...)
+		failures = append(failures, failed...)
+
+		// Stop processing once the context is cancelled rather than failing every remaining event.
+		if err != nil && ctx.Err() != nil {
+			break
+		}
+	}
+
+	return result, p.finish(ctx, &result, processed, failures)
+}
+
+// docsExist returns, for each of the passed events and their documents, whether the event has already been processed and the documents
+// to save once it has been. In correlation mode an event is also treated as processed if the same event was alerted on with a different
+// ID, such as when it was received by webhook and then fetched from the audit log.
+func (p *Processor) docsExist(ctx context.Context, events []github.AuditEvent, docs []state.Doc) ([]bool, [][]state.Doc, error) {
+	saves := make([][]state.Doc, len(docs))
+	lookup := append([]state.Doc(nil), docs...)
+	var checks [][]state.Doc
+
+	for i, doc := range docs {
+		saves[i] = []state.Doc{doc}
+		if !p.correlate {
+			continue
+		}
+
+		save, check := correlationDocs(events[i])
+		if len(check) > 0 {
+			saves[i] = append(saves[i], save)
+		}
+
+		checks = append(checks, check)
+		lookup = append(lookup, check...)
+	}
+
+	found, err := p.docsExistOnce(ctx, lookup)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	exists := found[:len(docs)]
+	offset := len(docs)
+	for i, check := range checks {
+		for range check {
+			exists[i] = exists[i] || found[offset]
+			offset++
 		}
 	}
+
+	return exists, saves, nil
 }
 
-func formatActor(actor github.Actor, capitalise bool) string {
-	actorName := ""
+// docsExistOnce returns whether each of the passed documents exists in the state store, looking up each document ID only once as
+// Firestore rejects requests for the same document more than once. Events in the same correlation window can share documents.
+func (p *Processor) docsExistOnce(ctx context.Context, docs []state.Doc) ([]bool, error) {
+	indexes := make(map[string]int)
+	var unique []state.Doc
+	for _, doc := range docs {
+		if _, ok := indexes[doc.ID]; !ok {
+			indexes[doc.ID] = len(unique)
+			unique = append(unique, doc)
+		}
+	}
+
+	found, err := p.store.DocsExist(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+
+	exists := make([]bool, len(docs))
+	for i, doc := range docs {
+		exists[i] = found[indexes[doc.ID]]
+	}
+
+	return exists, nil
+}
 
-	switch actor.Type {
-	case "Bot":
-		actorName = fmt.Sprintf("bot *%s*", actor.Login)
-		if capitalise {
-			actorName = fmt.Sprintf("Bot *%s*", actor.Login)
+// alert posts a Slack alert for the passed event and sends it to each of the processor's notifiers, skipping the sinks in the passed
+// set that it has already been delivered to. Every sink is tried even if an earlier one fails. The names of the sinks the alert was
+// sent to are returned along with the errors from any that failed.
+func (p *Processor) alert(ctx context.Context, e github.AuditEvent, info github.EventInfo, route routing.Route, timestamp, text string, delivered map[string]bool) ([]string, error) {
+	var sent []string
+	var errs []error
+	if p.router != nil && !delivered[slackSink] {
+		if err := p.postSlackAlert(ctx, e, info, route, timestamp, text); err != nil {
+			errs = append(errs, err)
+		} else {
+			sent = append(sent, slackSink)
 		}
+	}
 
-	case "Organization":
-		actorName = fmt.Sprintf("org *%s*", actor.Name)
-		if capitalise {
-			actorName = fmt.Sprintf("Org *%s*", actor.Name)
+	notified, notifyErrs := p.sendNotifications(ctx, newAlert(e, info, timestamp, text, p.webURL), delivered)
+	return append(sent, notified...), combineErrors(append(errs, notifyErrs...))
+}
+
+// postSlackAlert posts a Slack alert for the passed event.
+func (p *Processor) postSlackAlert(ctx context.Context, e github.AuditEvent, info github.EventInfo, route routing.Route, timestamp, text string) error {
+	payload := newSlackPayload(e, info, timestamp, text, route, p.webURL)
+
+	threadKey := strings.Join([]string{route.Channel, route.WebHookURL, subjectKey(e)}, "|")
+	payload.ThreadTS = p.threads[threadKey]
+
+	ts, err := p.postSlackMessage(ctx, payload, route)
+	if err != nil {
+		return err
+	}
+
+	if len(ts) > 0 && len(payload.ThreadTS) == 0 {
+		p.threads[threadKey] = ts
+	}
+
+	return nil
+}
+
+// sendNotifications sends the passed alert using each of the processor's notifiers not in the passed set of sinks already delivered to,
+// returning the names of the notifiers the alert was sent by and the errors from any that fail. Notifiers that filter out the alert
+// aren't tried, so aren't returned.
+func (p *Processor) sendNotifications(ctx context.Context, alert notify.Alert, delivered map[string]bool) ([]string, []error) {
+	var sent []string
+	var errs []error
+	for _, s := range p.sinks {
+		if delivered[s.name] {
+			continue
 		}
 
-	case "User":
-		actorName = fmt.Sprintf("user *%s*", actor.Login)
-		if capitalise {
-			actorName = fmt.Sprintf("User *%s*", actor.Login)
+		if f, ok := s.notifier.(notify.Filter); ok && !f.Accepts(alert) {
+			continue
 		}
 
-		if len(actor.Name) > 0 {
-			actorName = fmt.Sprintf("%s (%s)", actorName, actor.Name)
+		if err := s.notifier.Notify(ctx, alert); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to send %s notification", s.name))
+			continue
 		}
+
+		sent = append(sent, s.name)
 	}
 
-	return actorName
+	return sent, errs
 }
 
-func formatActorOrEmail(actor github.Actor, email string, capitalise bool) string {
-	if len(email) > 0 {
-		return fmt.Sprintf("*%s*", email)
+// saveDocs saves the passed documents to the state store. The documents are saved even if the passed context has been cancelled,
+// so events already alerted on aren't alerted on again by the next run.
+func (p *Processor) saveDocs(ctx context.Context, docs []state.Doc) error {
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), cancelledSaveTimeout)
+		defer cancel()
 	}
 
-	return formatActor(actor, capitalise)
+	return errors.Wrap(p.store.SaveDocs(ctx, docs), "failed to save documents to state store")
+}
+
+// allows returns whether the processor's rule set allows the passed event to be alerted on. Events of unknown actions, such as the
+// frequent git.* events in the REST audit log, are only alerted on if a rule includes them rather than by default. Each unknown action
+// is logged the first time it is seen so that rules can be written for it.
+func (p *Processor) allows(event github.AuditEvent) bool {
+	e := event.Node()
+	if event.Known() {
+		return p.ruleSet.Allows(event)
+	}
+
+	if !p.unknown[e.Action] {
+		p.unknown[e.Action] = true
+
+		// Using fmt rather than log so the output goes to STDOUT rather than STDERR.
+		fmt.Printf("Unknown GitHub event %s is only alerted on if a rule includes it\n", e.Action)
+	}
+
+	return p.ruleSet.Includes(event)
 }
 
 func logJSON(jsonData []byte) {
@@ -184,27 +357,32 @@ func logJSON(jsonData []byte) {
 	fmt.Println(string(jsonData))
 }
 
-func postSlackMessage(timestamp, text, slackAlertsChannel, slackWebHookURL string) {
-	payload := slack.Payload{
-		Text:      fmt.Sprintf("_%s_\n%s\n\n", timestamp, text),
-		Username:  "GitHub Auditor Bot",
-		Channel:   slackAlertsChannel,
-		IconEmoji: ":github:",
-	}
-
-	time.Sleep(slackRateLimitPause)
+// postSlackMessage posts the passed payload using the processor's Slack transport, or the incoming webhook of the passed route if the
+// processor doesn't have one. The timestamp of the posted message is returned if the transport supports it. The transports pace
+// messages to stay within Slack's rate limits.
+func (p *Processor) postSlackMessage(ctx context.Context, payload slack.Payload, route routing.Route) (string, error) {
+	transport := p.transport
+	if transport == nil {
+
+		// Webhook transports are reused so each webhook's rate limit is tracked across messages.
+		webhook, ok := p.webhooks[route.WebHookURL]
+		if !ok {
+			webhook = slack.NewWebhookTransport(route.WebHookURL)
+			p.webhooks[route.WebHookURL] = webhook
+		}
 
-	err := slack.Send(slackWebHookURL, payload)
-	if err != nil {
-		log.Fatalf("Failed to send Slack message: %v", err)
+		transport = webhook
 	}
+
+	ts, err := transport.Post(ctx, payload)
+	return ts, errors.Wrap(err, "failed to send Slack message")
 }
 
-func formatTime(s string) string {
+func formatTime(s string) (string, error) {
 	t, err := time.Parse(time.RFC3339, s)
 	if err != nil {
-		log.Fatalf("Failed to parse time '%s': %v", t, err)
+		return "", errors.Wrapf(err, "failed to parse time '%s'", s)
 	}
 
-	return t.Format("Monday 02 Jan 2006 15:04:05 MST")
+	return t.Format("Monday 02 Jan 2006 15:04:05 MST"), nil
 }
@@ -0,0 +1,186 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ONSdigital/github-auditor/internal/rules"
+	"github.com/ONSdigital/github-auditor/pkg/github"
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/state"
+)
+
+// recorder records the alerts it is notified of.
+type recorder struct {
+	alerts []notify.Alert
+}
+
+func (r *recorder) Notify(ctx context.Context, alert notify.Alert) error {
+	r.alerts = append(r.alerts, alert)
+	return nil
+}
+
+// failer fails to send every alert.
+type failer struct{}
+
+func (failer) Notify(ctx context.Context, alert notify.Alert) error {
+	return errors.New("unavailable")
+}
+
+// savingStore records the IDs of the documents saved to it.
+type savingStore struct {
+	*state.MemoryStore
+	saved []string
+}
+
+func (s *savingStore) SaveDocs(ctx context.Context, docs []state.Doc) error {
+	for _, doc := range docs {
+		s.saved = append(s.saved, doc.ID)
+	}
+
+	return s.MemoryStore.SaveDocs(ctx, docs)
+}
+
+// restEvents returns the events decoded from the passed page of REST audit log entries, fetched as the auditor fetches them.
+func restEvents(t *testing.T, entries string) []github.AuditEvent {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(entries))
+	}))
+
+	defer server.Close()
+
+	var events []github.AuditEvent
+	client := github.NewClient("token", github.WithBaseURL(server.URL))
+	err := client.StreamOrganizationRESTAuditEvents(context.Background(), "ons", "", "", func(page []github.AuditEvent) error {
+		events = append(events, page...)
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return events
+}
+
+func TestUnknownEventsAreOnlyAlertedOnIfIncluded(t *testing.T) {
+	ruleSet, err := rules.Parse([]byte(`{"rules": [{"effect": "include", "actions": ["protected_branch.*"]}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r := &recorder{}
+	p := NewProcessor(state.NewMemoryStore(), ruleSet, nil, nil)
+	p.AddNotifier("test", r)
+
+	result, err := p.Process(context.Background(), restEvents(t, `[
+		{"_document_id": "1", "action": "git.clone", "actor": "octocat", "repo": "ons/app", "@timestamp": 1591012800000},
+		{"_document_id": "2", "action": "protected_branch.destroy", "actor": "octocat", "repo": "ons/app", "@timestamp": 1591012800000}
+	]`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Alerted != 1 || result.Skipped != 1 {
+		t.Errorf("alerted %d and skipped %d events, want 1 of each", result.Alerted, result.Skipped)
+	}
+
+	want := "User *octocat* performed *protected_branch.destroy* on repo *ons/app*."
+	if len(r.alerts) != 1 || r.alerts[0].Text != want {
+		t.Errorf("alerts = %+v, want one with text %q", r.alerts, want)
+	}
+}
+
+func TestDigestListsEachEventsTarget(t *testing.T) {
+	r := &recorder{}
+	p := NewProcessor(state.NewMemoryStore(), nil, nil, nil)
+	p.AddNotifier("test", r)
+	p.EnableDigest(0)
+
+	var events []github.AuditEvent
+	for i, login := range []string{"alice", "bob"} {
+		events = append(events, github.EventForNode(github.Node{
+			ID:               fmt.Sprintf("%d", i),
+			Action:           "team.add_member",
+			Actor:            github.Actor{Type: "User", Login: "octocat"},
+			CreatedAt:        "2020-06-01T12:00:00Z",
+			OrganizationName: "ons",
+			TeamName:         "ons/platform",
+			User:             github.Actor{Type: "User", Login: login},
+		}))
+	}
+
+	if _, err := p.Process(context.Background(), events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := p.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Alerted != 2 {
+		t.Errorf("alerted %d events, want 2", result.Alerted)
+	}
+
+	want := "User *octocat* performed 2 *team.add_member* actions on team *ons/platform*.\n" +
+		"• _Monday 01 Jun 2020 12:00:00 UTC_ user *alice*\n" +
+		"• _Monday 01 Jun 2020 12:00:00 UTC_ user *bob*"
+
+	if len(r.alerts) != 1 || r.alerts[0].Text != want {
+		t.Errorf("alerts = %+v, want one with text %q", r.alerts, want)
+	}
+}
+
+func TestDeliveriesAreOnlyRecordedForAttemptedSinks(t *testing.T) {
+	store := &savingStore{MemoryStore: state.NewMemoryStore()}
+	r := &recorder{}
+	p := NewProcessor(store, nil, nil, nil)
+	p.AddNotifier("test", r)
+	p.AddNotifier("pagerduty", notify.WithSeverities(&recorder{}, string(github.Critical)))
+	p.AddNotifier("broken", failer{})
+
+	events := []github.AuditEvent{github.EventForNode(github.Node{
+		ID:        "1",
+		Action:    "team.add_member",
+		Actor:     github.Actor{Type: "User", Login: "octocat"},
+		CreatedAt: "2020-06-01T12:00:00Z",
+		TeamName:  "ons/platform",
+		User:      github.Actor{Type: "User", Login: "alice"},
+	})}
+
+	result, err := p.Process(context.Background(), events)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Undelivered != 1 || result.Failed != 0 {
+		t.Errorf("%d undelivered and %d failed, want 1 undelivered", result.Undelivered, result.Failed)
+	}
+
+	if want := []string{"1-test", "1-attempt-1"}; !reflect.DeepEqual(store.saved, want) {
+		t.Errorf("saved %v, want %v", store.saved, want)
+	}
+
+	// Once the broken sink recovers, the alert is only sent to it and only the event's document is saved.
+	p.sinks[2].notifier = r
+	store.saved = nil
+
+	if result, err = p.Process(context.Background(), events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Alerted != 1 || len(r.alerts) != 2 {
+		t.Errorf("alerted %d events with %d alerts recorded, want 1 event and 2 alerts", result.Alerted, len(r.alerts))
+	}
+
+	if want := []string{"1"}; !reflect.DeepEqual(store.saved, want) {
+		t.Errorf("saved %v, want %v", store.saved, want)
+	}
+}
@@ -0,0 +1,100 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ONSdigital/github-auditor/pkg/github"
+)
+
+type (
+
+	// Result summarises the outcome of processing a batch of GitHub audit events.
+	Result struct {
+		Processed   int     // Total number of events processed.
+		Alerted     int     // Events an alert was sent for.
+		Skipped     int     // Events that were already processed or aren't of interest.
+		Failed      int     // Events that couldn't be processed.
+		Undelivered int     // Events whose alert reached some sinks but not others.
+		Abandoned   int     // Events that were given up on after failing too many times.
+		Errors      []error // An *EventError for each failed, undelivered or abandoned event.
+	}
+
+	// EventError records the failure to process a single GitHub audit event.
+	EventError struct {
+		ID     string
+		Action string
+		Err    error
+	}
+)
+
+// Error returns a description of the failure including the ID and action of the event.
+func (e *EventError) Error() string {
+	return fmt.Sprintf("failed to process %s event %s: %v", e.Action, e.ID, e.Err)
+}
+
+// Cause returns the underlying error, for compatibility with errors.Cause.
+func (e *EventError) Cause() error {
+	return e.Err
+}
+
+// Unwrap returns the underlying error, for compatibility with errors.Is and errors.As.
+func (e *EventError) Unwrap() error {
+	return e.Err
+}
+
+// Add adds the counts and errors from the passed result to this result.
+func (r *Result) Add(other Result) {
+	r.Processed += other.Processed
+	r.Alerted += other.Alerted
+	r.Skipped += other.Skipped
+	r.Failed += other.Failed
+	r.Undelivered += other.Undelivered
+	r.Abandoned += other.Abandoned
+	r.Errors = append(r.Errors, other.Errors...)
+}
+
+func (r *Result) fail(e github.Node, err error) {
+	r.Failed++
+	r.Errors = append(r.Errors, &EventError{
+		ID:     e.ID,
+		Action: e.Action,
+		Err:    err,
+	})
+}
+
+func (r *Result) undeliver(e github.Node, err error) {
+	r.Undelivered++
+	r.Errors = append(r.Errors, &EventError{
+		ID:     e.ID,
+		Action: e.Action,
+		Err:    err,
+	})
+}
+
+func (r *Result) abandon(e github.Node, attempts int, err error) {
+	r.Abandoned++
+	r.Errors = append(r.Errors, &EventError{
+		ID:     e.ID,
+		Action: e.Action,
+		Err:    fmt.Errorf("giving up after %d attempts: %w", attempts, err),
+	})
+}
+
+// combineErrors returns the passed errors combined into a single error, or nil if there are none.
+func combineErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+
+	return errors.New(strings.Join(messages, "; "))
+}
@@ -0,0 +1,146 @@
+package receiver
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/github-auditor/internal/event"
+	"github.com/ONSdigital/github-auditor/pkg/github"
+)
+
+const (
+
+	// maxPayloadSize is the largest webhook payload GitHub sends. See https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads.
+	maxPayloadSize = 25 << 20
+
+	// queueSize is the number of accepted deliveries that can wait to be processed before further deliveries are refused.
+	queueSize = 100
+
+	// processTimeout limits how long processing a single delivery can take, including retrying failed alerts.
+	processTimeout = 5 * time.Minute
+)
+
+type (
+
+	// Handler receives GitHub organisation webhooks, validating their signatures and queueing the equivalent audit log events to be
+	// processed by Run. Deliveries are acknowledged once queued, as GitHub gives up on deliveries that take more than ten seconds to
+	// respond to and sending an alert can take longer than that when it is retried.
+	Handler struct {
+		secret    []byte
+		processor *event.Processor
+		queue     chan delivery
+	}
+
+	// delivery is a webhook delivery waiting to be processed.
+	delivery struct {
+		eventType string
+		id        string
+		events    []github.AuditEvent
+	}
+)
+
+// NewHandler instantiates a handler validating webhook signatures using the passed secret and processing events using the passed
+// processor, which mustn't be used by anything else.
+func NewHandler(secret string, processor *event.Processor) *Handler {
+	return &Handler{
+		secret:    []byte(secret),
+		processor: processor,
+		queue:     make(chan delivery, queueSize),
+	}
+}
+
+// ServeHTTP handles a webhook delivery. Deliveries without a valid X-Hub-Signature-256 header are rejected, and valid deliveries are
+// responded to with 202 Accepted once queued. If the queue is full the delivery is responded to with a server error so it shows as
+// failed in GitHub, leaving its events to be alerted on when the audit log is next polled.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	receivedAt := time.Now()
+	payload, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, "failed to read payload", http.StatusBadRequest)
+		return
+	}
+
+	if !github.ValidWebhookSignature(h.secret, payload, r.Header.Get("X-Hub-Signature-256")) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	eventType := r.Header.Get("X-GitHub-Event")
+	deliveryID := r.Header.Get("X-GitHub-Delivery")
+	if eventType == "ping" {
+		fmt.Fprintln(w, "pong")
+		return
+	}
+
+	events, err := github.WebhookEvents(eventType, deliveryID, payload, receivedAt)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(events) == 0 {
+		fmt.Fprintf(w, "ignored %s event\n", eventType)
+		return
+	}
+
+	select {
+	case h.queue <- delivery{eventType: eventType, id: deliveryID, events: events}:
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintln(w, "accepted")
+	default:
+		log.Printf("Refused %s webhook delivery %s as %d deliveries are waiting to be processed", eventType, deliveryID, queueSize)
+		http.Error(w, "too many deliveries waiting to be processed", http.StatusServiceUnavailable)
+	}
+}
+
+// Run processes queued deliveries one at a time until the passed context is cancelled. Each delivery is processed using a context
+// detached from the passed one so that a delivery being processed at shutdown is finished rather than abandoned part way through.
+// Deliveries still queued at shutdown are left to be alerted on when the audit log is next polled.
+func (h *Handler) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			if len(h.queue) > 0 {
+				log.Printf("Shutting down with %d webhook deliveries waiting to be processed", len(h.queue))
+			}
+
+			return
+
+		case d := <-h.queue:
+			h.process(d)
+		}
+	}
+}
+
+// process processes the events of the passed delivery, logging the outcome.
+func (h *Handler) process(d delivery) {
+	ctx, cancel := context.WithTimeout(context.Background(), processTimeout)
+	defer cancel()
+
+	result, err := h.processor.Process(ctx, d.events)
+
+	// Alerts for separate deliveries aren't threaded together, which also stops the processor's record of threads growing forever.
+	h.processor.ResetThreads()
+
+	for _, e := range result.Errors {
+		log.Println(e)
+	}
+
+	if err != nil || result.Failed > 0 {
+		log.Printf("Failed to process %s webhook delivery %s: %v", d.eventType, d.id, err)
+		return
+	}
+
+	// Using fmt rather than log so the output goes to STDOUT rather than STDERR.
+	fmt.Printf("Processed %s webhook delivery %s: %d alerted, %d skipped\n", d.eventType, d.id, result.Alerted, result.Skipped)
+}
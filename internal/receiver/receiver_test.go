@@ -0,0 +1,106 @@
+package receiver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/github-auditor/internal/event"
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/state"
+)
+
+const (
+	secret  = "secret"
+	payload = `{
+		"action": "added",
+		"sender": {"login": "alice", "type": "User"},
+		"organization": {"login": "ons"},
+		"repository": {"full_name": "ons/app", "private": true},
+		"member": {"login": "bob", "type": "User"}
+	}`
+)
+
+// notifier sends each alert it is notified of to a channel.
+type notifier chan notify.Alert
+
+func (n notifier) Notify(ctx context.Context, alert notify.Alert) error {
+	n <- alert
+	return nil
+}
+
+func sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliver(h http.Handler, signature string) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+	req.Header.Set("X-GitHub-Event", "member")
+	req.Header.Set("X-GitHub-Delivery", "1")
+	req.Header.Set("X-Hub-Signature-256", signature)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestHandlerRejectsInvalidSignatures(t *testing.T) {
+	h := NewHandler(secret, event.NewProcessor(state.NewMemoryStore(), nil, nil, nil))
+
+	if got := deliver(h, "sha256=00"); got != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", got, http.StatusUnauthorized)
+	}
+
+	if len(h.queue) != 0 {
+		t.Errorf("%d deliveries queued, want none", len(h.queue))
+	}
+}
+
+func TestHandlerProcessesAcceptedDeliveries(t *testing.T) {
+	alerts := make(notifier, 1)
+	processor := event.NewProcessor(state.NewMemoryStore(), nil, nil, nil)
+	processor.AddNotifier("test", alerts)
+	h := NewHandler(secret, processor)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go h.Run(ctx)
+
+	if got := deliver(h, sign(payload)); got != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d", got, http.StatusAccepted)
+	}
+
+	select {
+	case alert := <-alerts:
+		if !strings.Contains(alert.Text, "bob") {
+			t.Errorf("alert text %q doesn't mention the added member", alert.Text)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Error("delivery wasn't processed")
+	}
+}
+
+func TestHandlerRefusesDeliveriesWhenQueueIsFull(t *testing.T) {
+	h := NewHandler(secret, event.NewProcessor(state.NewMemoryStore(), nil, nil, nil))
+
+	// Nothing processes the queue, so it fills up.
+	for i := 0; i < queueSize; i++ {
+		if got := deliver(h, sign(payload)); got != http.StatusAccepted {
+			t.Fatalf("status = %d, want %d", got, http.StatusAccepted)
+		}
+	}
+
+	if got := deliver(h, sign(payload)); got != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", got, http.StatusServiceUnavailable)
+	}
+}
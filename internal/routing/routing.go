@@ -0,0 +1,168 @@
+package routing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ONSdigital/github-auditor/pkg/github"
+	"github.com/pkg/errors"
+)
+
+var (
+
+	// severities are the severities routes can match.
+	severities = map[github.Severity]bool{github.Info: true, github.Warning: true, github.Critical: true}
+
+	// categories are the categories routes can match.
+	categories = map[github.Category]bool{github.CategoryOAuth: true, github.CategoryOrg: true, github.CategoryRepo: true, github.CategoryTeam: true}
+)
+
+type (
+
+	// Route is a destination for alerts. A route matches an alert when all of its non-empty organisation, severity and category lists
+	// contain the alert's organisation, severity and category. Empty Channel and WebHookURL fields fall back to those of the default route.
+	Route struct {
+		Name            string            `json:"name,omitempty"`
+		Organisations   []string          `json:"organisations,omitempty"` // Organisation names are matched case-insensitively.
+		Severities      []github.Severity `json:"severities,omitempty"`
+		Categories      []github.Category `json:"categories,omitempty"`
+		Channel         string            `json:"channel,omitempty"`
+		WebHookURL      string            `json:"webhook,omitempty"`
+		CriticalMention string            `json:"criticalMention,omitempty"` // Prepended to critical alerts, e.g. <!subteam^S0123ABC> for a user group.
+	}
+
+	// Router chooses the route for each alert. Routes are evaluated in order and the first matching route is used. If no route
+	// matches, the default route is used.
+	Router struct {
+		Default Route   `json:"default"`
+		Routes  []Route `json:"routes"`
+	}
+)
+
+// NewRouter instantiates a router that sends every alert to the passed default route.
+func NewRouter(defaultRoute Route) *Router {
+	return &Router{Default: defaultRoute}
+}
+
+// Load reads the JSON routing file at the passed path. Fields of the default route not set in the file are taken from the passed default route.
+// Routes with an unknown severity or category are rejected, as they would otherwise never match.
+func Load(filename string, defaultRoute Route) (*Router, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read routing file %s", filename)
+	}
+
+	router := NewRouter(defaultRoute)
+	if err := json.Unmarshal(data, router); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse routing file %s", filename)
+	}
+
+	for i, route := range router.Routes {
+		if err := route.validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid route %d (%s) in routing file %s", i+1, route.Name, filename)
+		}
+	}
+
+	router.Default = router.Default.withDefaults(defaultRoute)
+	return router, nil
+}
+
+// Route returns the route for an alert for the passed organisation with the passed category and severity.
+func (r *Router) Route(organisation string, category github.Category, severity github.Severity) Route {
+	for _, route := range r.Routes {
+		if route.matches(organisation, category, severity) {
+			return route.withDefaults(r.Default)
+		}
+	}
+
+	return r.Default
+}
+
+// Mention returns the text to prepend to an alert with the passed severity sent using the route, which is empty unless the alert is critical.
+func (r Route) Mention(severity github.Severity) string {
+	if severity == github.Critical {
+		return r.CriticalMention
+	}
+
+	return ""
+}
+
+func (r Route) matches(organisation string, category github.Category, severity github.Severity) bool {
+	if len(r.Organisations) > 0 && !containsOrganisation(r.Organisations, organisation) {
+		return false
+	}
+
+	if len(r.Severities) > 0 && !containsSeverity(r.Severities, severity) {
+		return false
+	}
+
+	if len(r.Categories) > 0 && !containsCategory(r.Categories, category) {
+		return false
+	}
+
+	return true
+}
+
+func (r Route) validate() error {
+	for _, severity := range r.Severities {
+		if !severities[severity] {
+			return fmt.Errorf("unknown severity '%s' (expected %s, %s or %s)", severity, github.Info, github.Warning, github.Critical)
+		}
+	}
+
+	for _, category := range r.Categories {
+		if !categories[category] {
+			return fmt.Errorf("unknown category '%s' (expected %s, %s, %s or %s)", category, github.CategoryOAuth, github.CategoryOrg, github.CategoryRepo, github.CategoryTeam)
+		}
+	}
+
+	return nil
+}
+
+func (r Route) withDefaults(defaultRoute Route) Route {
+	if len(r.Channel) == 0 {
+		r.Channel = defaultRoute.Channel
+	}
+
+	if len(r.WebHookURL) == 0 {
+		r.WebHookURL = defaultRoute.WebHookURL
+	}
+
+	if len(r.CriticalMention) == 0 {
+		r.CriticalMention = defaultRoute.CriticalMention
+	}
+
+	return r
+}
+
+func containsOrganisation(organisations []string, organisation string) bool {
+	for _, o := range organisations {
+		if strings.EqualFold(o, organisation) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsSeverity(severities []github.Severity, severity github.Severity) bool {
+	for _, s := range severities {
+		if s == severity {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsCategory(categories []github.Category, category github.Category) bool {
+	for _, c := range categories {
+		if c == category {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,164 @@
+package routing
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/github-auditor/pkg/github"
+)
+
+// writeRoutes writes the passed routing file to a temporary directory, returning its path.
+func writeRoutes(t *testing.T, data string) string {
+	dir, err := ioutil.TempDir("", "routing")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	filename := filepath.Join(dir, "routes.json")
+	if err := ioutil.WriteFile(filename, []byte(data), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return filename
+}
+
+var defaultRoute = Route{Channel: "#alerts", WebHookURL: "https://hooks.slack.com/default", CriticalMention: "<!here>"}
+
+func TestRoute(t *testing.T) {
+	router := &Router{
+		Default: defaultRoute,
+		Routes: []Route{
+			{Name: "ons critical", Organisations: []string{"ONS"}, Severities: []github.Severity{github.Critical}, Channel: "#ons-critical"},
+			{Name: "teams", Categories: []github.Category{github.CategoryTeam}, Channel: "#teams", WebHookURL: "https://hooks.slack.com/teams"},
+			{Name: "critical", Severities: []github.Severity{github.Critical}, CriticalMention: "<!subteam^S0123ABC>"},
+		},
+	}
+
+	tests := []struct {
+		organisation string
+		category     github.Category
+		severity     github.Severity
+		want         Route
+	}{
+		{
+			organisation: "ons",
+			category:     github.CategoryRepo,
+			severity:     github.Critical,
+			want:         Route{Name: "ons critical", Channel: "#ons-critical", WebHookURL: "https://hooks.slack.com/default", CriticalMention: "<!here>"},
+		},
+		{
+			organisation: "ons",
+			category:     github.CategoryTeam,
+			severity:     github.Critical,
+			want:         Route{Name: "ons critical", Channel: "#ons-critical", WebHookURL: "https://hooks.slack.com/default", CriticalMention: "<!here>"},
+		},
+		{
+			organisation: "ons",
+			category:     github.CategoryTeam,
+			severity:     github.Info,
+			want:         Route{Name: "teams", Channel: "#teams", WebHookURL: "https://hooks.slack.com/teams", CriticalMention: "<!here>"},
+		},
+		{
+			organisation: "other",
+			category:     github.CategoryOrg,
+			severity:     github.Critical,
+			want:         Route{Name: "critical", Channel: "#alerts", WebHookURL: "https://hooks.slack.com/default", CriticalMention: "<!subteam^S0123ABC>"},
+		},
+		{
+			organisation: "ons",
+			category:     github.CategoryRepo,
+			severity:     github.Warning,
+			want:         defaultRoute,
+		},
+	}
+
+	for _, test := range tests {
+		got := router.Route(test.organisation, test.category, test.severity)
+		if got.Name != test.want.Name || got.Channel != test.want.Channel || got.WebHookURL != test.want.WebHookURL ||
+			got.CriticalMention != test.want.CriticalMention {
+			t.Errorf("Route(%q, %q, %q) = %+v, want %+v", test.organisation, test.category, test.severity, got, test.want)
+		}
+	}
+}
+
+func TestWithDefaults(t *testing.T) {
+	tests := []struct {
+		route Route
+		want  Route
+	}{
+		{route: Route{}, want: defaultRoute},
+		{route: Route{Channel: "#repos"}, want: Route{Channel: "#repos", WebHookURL: "https://hooks.slack.com/default", CriticalMention: "<!here>"}},
+		{
+			route: Route{Channel: "#repos", WebHookURL: "https://hooks.slack.com/repos", CriticalMention: "<!channel>"},
+			want:  Route{Channel: "#repos", WebHookURL: "https://hooks.slack.com/repos", CriticalMention: "<!channel>"},
+		},
+	}
+
+	for _, test := range tests {
+		if got := test.route.withDefaults(defaultRoute); got.Channel != test.want.Channel || got.WebHookURL != test.want.WebHookURL ||
+			got.CriticalMention != test.want.CriticalMention {
+			t.Errorf("%+v.withDefaults() = %+v, want %+v", test.route, got, test.want)
+		}
+	}
+}
+
+func TestMention(t *testing.T) {
+	route := Route{CriticalMention: "<!here>"}
+	tests := []struct {
+		severity github.Severity
+		want     string
+	}{
+		{github.Info, ""},
+		{github.Warning, ""},
+		{github.Critical, "<!here>"},
+	}
+
+	for _, test := range tests {
+		if got := route.Mention(test.severity); got != test.want {
+			t.Errorf("Mention(%q) = %q, want %q", test.severity, got, test.want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	filename := writeRoutes(t, `{
+		"default": {"channel": "#default"},
+		"routes": [{"name": "critical", "severities": ["critical"], "categories": ["repo", "team"], "channel": "#critical"}]
+	}`)
+
+	router, err := Load(filename, defaultRoute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := Route{Channel: "#default", WebHookURL: "https://hooks.slack.com/default", CriticalMention: "<!here>"}
+	if router.Default.Channel != want.Channel || router.Default.WebHookURL != want.WebHookURL || router.Default.CriticalMention != want.CriticalMention {
+		t.Errorf("default route = %+v, want %+v", router.Default, want)
+	}
+
+	if got := router.Route("ons", github.CategoryRepo, github.Critical); got.Channel != "#critical" {
+		t.Errorf("critical repo alert routed to %q, want #critical", got.Channel)
+	}
+}
+
+func TestLoadRejectsUnknownValues(t *testing.T) {
+	tests := []struct {
+		routes string
+		want   string
+	}{
+		{routes: `[{"name": "typo", "severities": ["critcal"]}]`, want: "unknown severity 'critcal'"},
+		{routes: `[{"name": "ok", "severities": ["info"]}, {"name": "typo", "categories": ["repos"]}]`, want: "invalid route 2 (typo)"},
+	}
+
+	for _, test := range tests {
+		_, err := Load(writeRoutes(t, `{"routes": `+test.routes+`}`), defaultRoute)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: error = %v, want one containing %q", test.routes, err, test.want)
+		}
+	}
+}
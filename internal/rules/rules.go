@@ -0,0 +1,199 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ONSdigital/github-auditor/pkg/github"
+	"github.com/pkg/errors"
+)
+
+type (
+
+	// Effect is the outcome of a rule matching an event.
+	Effect string
+
+	// Rule matches GitHub audit events on their fields. Each field is a list of case-insensitive glob patterns, in which * matches
+	// any sequence of characters (including /) and ? matches any single character, and matches if any of its patterns match.
+	// Empty fields match everything. A rule matches an event when all of its non-empty fields match.
+	Rule struct {
+		Name         string   `json:"name,omitempty"`
+		Effect       Effect   `json:"effect"`
+		Actions      []string `json:"actions,omitempty"`
+		Actors       []string `json:"actors,omitempty"`
+		ActorTypes   []string `json:"actorTypes,omitempty"`
+		Repositories []string `json:"repositories,omitempty"`
+		Teams        []string `json:"teams,omitempty"`
+		Visibilities []string `json:"visibilities,omitempty"`
+		Permissions  []string `json:"permissions,omitempty"`
+	}
+
+	// RuleSet is an ordered list of rules deciding which GitHub audit events are alerted on. Rules are evaluated in order
+	// and the first matching rule decides the outcome. If no rule matches, the default effect applies.
+	RuleSet struct {
+		Default Effect `json:"default,omitempty"`
+		Rules   []Rule `json:"rules"`
+	}
+)
+
+const (
+
+	// Include causes an alert to be created for a matching event.
+	Include Effect = "include"
+
+	// Exclude prevents an alert being created for a matching event.
+	Exclude Effect = "exclude"
+)
+
+// Load reads and parses the JSON rules file at the passed path.
+func Load(filename string) (*RuleSet, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read rules file %s", filename)
+	}
+
+	ruleSet, err := Parse(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse rules file %s", filename)
+	}
+
+	return ruleSet, nil
+}
+
+// Parse parses and validates the passed JSON rule set. The default effect is Include if not set.
+func Parse(data []byte) (*RuleSet, error) {
+	var ruleSet RuleSet
+	if err := json.Unmarshal(data, &ruleSet); err != nil {
+		return nil, err
+	}
+
+	if len(ruleSet.Default) == 0 {
+		ruleSet.Default = Include
+	}
+
+	if err := validateEffect(ruleSet.Default); err != nil {
+		return nil, errors.Wrap(err, "invalid default")
+	}
+
+	for i, rule := range ruleSet.Rules {
+		if err := rule.validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid rule %d (%s)", i+1, rule.Name)
+		}
+	}
+
+	return &ruleSet, nil
+}
+
+// Allows returns whether the passed event should be alerted on. A nil rule set allows every event.
+func (rs *RuleSet) Allows(e github.AuditEvent) bool {
+	if rs == nil {
+		return true
+	}
+
+	if rule := rs.Match(e); rule != nil {
+		return rule.Effect == Include
+	}
+
+	return rs.Default != Exclude
+}
+
+// Includes returns whether the first rule in the rule set matching the passed event includes it, ignoring the default effect. A nil
+// rule set includes nothing.
+func (rs *RuleSet) Includes(e github.AuditEvent) bool {
+	rule := rs.Match(e)
+	return rule != nil && rule.Effect == Include
+}
+
+// Match returns the first rule in the rule set matching the passed event, or nil if no rule matches.
+func (rs *RuleSet) Match(e github.AuditEvent) *Rule {
+	if rs == nil {
+		return nil
+	}
+
+	for i := range rs.Rules {
+		if rs.Rules[i].Matches(e) {
+			return &rs.Rules[i]
+		}
+	}
+
+	return nil
+}
+
+// Matches returns whether all the non-empty fields of the rule match the passed event. The actor fields match the event's subject.
+func (r Rule) Matches(e github.AuditEvent) bool {
+	subject := e.Subject()
+	n := e.Node()
+	return matchAny(r.Actions, n.Action) &&
+		matchAny(r.Actors, subject.Login, subject.Name) &&
+		matchAny(r.ActorTypes, subject.Type) &&
+		matchAny(r.Repositories, n.RepositoryName) &&
+		matchAny(r.Teams, n.TeamName) &&
+		matchAny(r.Visibilities, n.Visibility) &&
+		matchAny(r.Permissions, n.Permission)
+}
+
+func (r Rule) validate() error {
+	return validateEffect(r.Effect)
+}
+
+func validateEffect(effect Effect) error {
+	if effect != Include && effect != Exclude {
+		return fmt.Errorf("unknown effect '%s' (expected %s or %s)", effect, Include, Exclude)
+	}
+
+	return nil
+}
+
+// matchAny returns whether any of the passed patterns match any of the passed non-empty values. An empty list of patterns matches everything.
+func matchAny(patterns []string, values ...string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range patterns {
+		for _, value := range values {
+			if len(value) == 0 {
+				continue
+			}
+
+			if glob(strings.ToLower(pattern), strings.ToLower(value)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// glob returns whether the passed value matches the passed pattern, in which * matches any sequence of characters and ? matches any single character.
+func glob(pattern, value string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := len(value); i >= 0; i-- {
+				if glob(pattern[1:], value[i:]) {
+					return true
+				}
+			}
+
+			return false
+
+		case '?':
+			if len(value) == 0 {
+				return false
+			}
+
+		default:
+			if len(value) == 0 || pattern[0] != value[0] {
+				return false
+			}
+		}
+
+		pattern = pattern[1:]
+		value = value[1:]
+	}
+
+	return len(value) == 0
+}
@@ -0,0 +1,225 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/github-auditor/pkg/github"
+)
+
+func TestGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"repo.create", "repo.create", true},
+		{"repo.create", "repo.destroy", false},
+		{"repo.*", "repo.create", true},
+		{"repo.*", "repo.", true},
+		{"repo.*", "org.add_member", false},
+		{"*", "", true},
+		{"*", "anything/at/all", true},
+		{"ons/*", "ons/team/sub-team", true},
+		{"*.enable", "org.enable_saml", false},
+		{"*_saml", "org.enable_saml", true},
+		{"a*b*c", "axxbyyc", true},
+		{"a*b*c", "axxbyy", false},
+		{"?", "", false},
+		{"?", "a", true},
+		{"?", "ab", false},
+		{"repo.?rchived", "repo.archived", true},
+		{"", "", true},
+		{"", "a", false},
+	}
+
+	for _, test := range tests {
+		if got := glob(test.pattern, test.value); got != test.want {
+			t.Errorf("glob(%q, %q) = %v, want %v", test.pattern, test.value, got, test.want)
+		}
+	}
+}
+
+func TestMatchAny(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		values   []string
+		want     bool
+	}{
+		{"no patterns match everything", nil, []string{""}, true},
+		{"case is folded", []string{"ONSdigital/*"}, []string{"onsdigital/GitHub-Auditor"}, true},
+		{"any pattern matches", []string{"admin", "write"}, []string{"WRITE"}, true},
+		{"no pattern matches", []string{"admin", "write"}, []string{"read"}, false},
+		{"any value matches", []string{"octocat"}, []string{"", "octocat"}, true},
+		{"empty values never match", []string{"*"}, []string{""}, false},
+	}
+
+	for _, test := range tests {
+		if got := matchAny(test.patterns, test.values...); got != test.want {
+			t.Errorf("%s: matchAny(%q, %q) = %v, want %v", test.name, test.patterns, test.values, got, test.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		json        string
+		wantDefault Effect
+		wantErr     bool
+	}{
+		{"default is include if not set", `{"rules": []}`, Include, false},
+		{"default can be exclude", `{"default": "exclude", "rules": []}`, Exclude, false},
+		{"unknown default", `{"default": "maybe", "rules": []}`, "", true},
+		{"rule without effect", `{"rules": [{"actions": ["repo.*"]}]}`, "", true},
+		{"rule with unknown effect", `{"rules": [{"effect": "alert", "actions": ["repo.*"]}]}`, "", true},
+		{"valid rules", `{"rules": [{"effect": "exclude", "actors": ["*[bot]"]}, {"effect": "include"}]}`, Include, false},
+		{"invalid JSON", `{"rules": [`, "", true},
+	}
+
+	for _, test := range tests {
+		ruleSet, err := Parse([]byte(test.json))
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.name)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		if ruleSet.Default != test.wantDefault {
+			t.Errorf("%s: default = %s, want %s", test.name, ruleSet.Default, test.wantDefault)
+		}
+	}
+}
+
+func TestAllows(t *testing.T) {
+	ruleSet, err := Parse([]byte(`{
+		"default": "exclude",
+		"rules": [
+			{"name": "ignore bots", "effect": "exclude", "actorTypes": ["Bot"]},
+			{"name": "ignore sandbox", "effect": "exclude", "actions": ["repo.*"], "repositories": ["ons/sandbox-*"]},
+			{"name": "repos", "effect": "include", "actions": ["repo.*"]},
+			{"name": "admins", "effect": "include", "actions": ["org.update_member"], "permissions": ["admin"]},
+			{"name": "octocat", "effect": "include", "actors": ["The Octocat"]}
+		]
+	}`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	user := github.Actor{Type: "User", Login: "someone"}
+	tests := []struct {
+		name     string
+		event    github.Node
+		wantRule string
+		want     bool
+	}{
+		{
+			name:     "first matching rule wins",
+			event:    github.Node{Action: "repo.create", Actor: github.Actor{Type: "Bot", Login: "ci[bot]"}, RepositoryName: "ons/app"},
+			wantRule: "ignore bots",
+			want:     false,
+		},
+		{
+			name:     "all fields of a rule must match",
+			event:    github.Node{Action: "repo.create", Actor: user, RepositoryName: "ons/sandbox-test"},
+			wantRule: "ignore sandbox",
+			want:     false,
+		},
+		{
+			name:     "later rule matches",
+			event:    github.Node{Action: "repo.create", Actor: user, RepositoryName: "ons/app"},
+			wantRule: "repos",
+			want:     true,
+		},
+		{
+			name:     "permission is case-insensitive",
+			event:    github.Node{Action: "org.update_member", Actor: user, Permission: "ADMIN"},
+			wantRule: "admins",
+			want:     true,
+		},
+		{
+			name:     "empty field doesn't match a pattern",
+			event:    github.Node{Action: "org.update_member", Actor: user},
+			wantRule: "",
+			want:     false,
+		},
+		{
+			name:     "actor matches on name as well as login",
+			event:    github.Node{Action: "org.block_user", Actor: github.Actor{Type: "User", Login: "octocat", Name: "The Octocat"}},
+			wantRule: "octocat",
+			want:     true,
+		},
+		{
+			name:     "default applies when no rule matches",
+			event:    github.Node{Action: "team.add_member", Actor: user},
+			wantRule: "",
+			want:     false,
+		},
+	}
+
+	for _, test := range tests {
+		rule := ruleSet.Match(github.EventForNode(test.event))
+		switch {
+		case rule == nil && len(test.wantRule) > 0:
+			t.Errorf("%s: no rule matched, want %s", test.name, test.wantRule)
+		case rule != nil && rule.Name != test.wantRule:
+			t.Errorf("%s: rule %s matched, want %q", test.name, rule.Name, test.wantRule)
+		}
+
+		if got := ruleSet.Allows(github.EventForNode(test.event)); got != test.want {
+			t.Errorf("%s: Allows = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestNilRuleSetAllowsEverything(t *testing.T) {
+	var ruleSet *RuleSet
+	if !ruleSet.Allows(github.EventForNode(github.Node{Action: "repo.create"})) {
+		t.Error("nil rule set should allow every event")
+	}
+
+	if ruleSet.Match(github.EventForNode(github.Node{Action: "repo.create"})) != nil {
+		t.Error("nil rule set shouldn't match any rule")
+	}
+}
+
+func TestIncludes(t *testing.T) {
+	ruleSet, err := Parse([]byte(`{
+		"rules": [
+			{"effect": "exclude", "actions": ["git.clone"]},
+			{"effect": "include", "actions": ["git.*"]}
+		]
+	}`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		action string
+		want   bool
+	}{
+		{"git.push", true},
+		{"git.clone", false},
+		{"protected_branch.destroy", false}, // The default effect doesn't include events.
+	}
+
+	for _, test := range tests {
+		if got := ruleSet.Includes(github.EventForNode(github.Node{Action: test.action})); got != test.want {
+			t.Errorf("Includes(%s) = %v, want %v", test.action, got, test.want)
+		}
+	}
+
+	var nilRuleSet *RuleSet
+	if nilRuleSet.Includes(github.EventForNode(github.Node{Action: "git.push"})) {
+		t.Error("nil rule set shouldn't include any event")
+	}
+}
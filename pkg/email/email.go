@@ -0,0 +1,208 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/retry"
+	"github.com/pkg/errors"
+)
+
+// sendTimeout limits how long sending an email can take, so an unresponsive SMTP server can't hold up alerting.
+const sendTimeout = 1 * time.Minute
+
+// Notifier sends alerts as plain text emails using an SMTP server. The connection is upgraded using STARTTLS if the server supports it.
+type Notifier struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+// NewNotifier instantiates a notifier sending alerts from the passed address to the passed recipients using the SMTP server at the
+// passed host:port address. The passed username and password are used to authenticate if the username isn't empty.
+func NewNotifier(addr, username, password, from string, to []string) (*Notifier, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid SMTP server address '%s'", addr)
+	}
+
+	if len(from) == 0 {
+		return nil, errors.New("missing email sender address")
+	}
+
+	if len(to) == 0 {
+		return nil, errors.New("missing email recipient addresses")
+	}
+
+	return &Notifier{
+		addr:     addr,
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+		to:       to,
+	}, nil
+}
+
+// Notify emails the passed alert to the notifier's recipients. Transient failures, such as the SMTP server being unreachable or
+// replying with a 4xx code, are retried using retry.Backoff, up to retry.MaxAttempts times in all.
+func (n *Notifier) Notify(ctx context.Context, alert notify.Alert) error {
+	subject, body := alert.Title(), NewBody(alert)
+	for attempt := 1; ; attempt++ {
+		err := n.send(ctx, subject, body)
+		if err == nil || ctx.Err() != nil || attempt == retry.MaxAttempts || !transient(err) {
+			return errors.Wrap(err, "failed to send email")
+		}
+
+		delay := retry.Backoff(attempt)
+		log.Printf("Sending email using %s failed: %v, retrying in %v (attempt %d of %d)", n.addr, err, delay, attempt, retry.MaxAttempts)
+
+		if err := retry.Wait(ctx, delay); err != nil {
+			return errors.Wrap(err, "failed to send email")
+		}
+	}
+}
+
+// transient returns whether the passed error sending an email may not recur if it is sent again: a failure to connect to the SMTP
+// server or a transient negative reply (a 4xx code, such as a recipient being greylisted).
+func transient(err error) bool {
+	switch err := errors.Cause(err).(type) {
+	case *textproto.Error:
+		return err.Code >= 400 && err.Code < 500
+	case *net.OpError:
+		return true
+	}
+
+	return false
+}
+
+func (n *Notifier) send(ctx context.Context, subject, body string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	// The SMTP client doesn't support contexts, so the connection is given the context's deadline and closed if the context is
+	// cancelled, which fails any I/O in progress.
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	// Report the cancellation rather than the I/O error it caused.
+	defer func() {
+		if err != nil && ctx.Err() != nil {
+			err = ctx.Err()
+		}
+	}()
+
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+			return errors.Wrap(err, "failed to start TLS")
+		}
+	}
+
+	if len(n.username) > 0 {
+		if err := client.Auth(smtp.PlainAuth("", n.username, n.password, n.host)); err != nil {
+			return errors.Wrap(err, "failed to authenticate")
+		}
+	}
+
+	if err := client.Mail(n.from); err != nil {
+		return err
+	}
+
+	for _, to := range n.to {
+		if err := client.Rcpt(to); err != nil {
+			return errors.Wrapf(err, "recipient %s was rejected", to)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(n.newMessage(subject, body)); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// newMessage returns the RFC 5322 message with the passed subject and plain text body.
+func (n *Notifier) newMessage(subject, body string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", n.from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(n.to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.Replace(body, "\n", "\r\n", -1))
+	return b.Bytes()
+}
+
+// NewBody returns the plain text email body for the passed alert: the alert text followed by its fields and links.
+func NewBody(alert notify.Alert) string {
+	var b strings.Builder
+	b.WriteString(notify.PlainText(alert.Text))
+	b.WriteString("\n")
+
+	if len(alert.Fields) > 0 {
+		b.WriteString("\n")
+		for _, field := range alert.Fields {
+			fmt.Fprintf(&b, "%s: %s\n", field.Title, notify.PlainText(field.Value))
+		}
+	}
+
+	if len(alert.Links) > 0 {
+		b.WriteString("\n")
+		for _, link := range alert.Links {
+			fmt.Fprintf(&b, "%s: %s\n", link.Text, link.URL)
+		}
+	}
+
+	return b.String()
+}
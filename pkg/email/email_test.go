@@ -0,0 +1,206 @@
+package email
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/pkg/errors"
+)
+
+// smtpServer is an SMTP stand-in that records the messages it accepts. Each connection's recipients are replied to with the next of
+// its reply codes for them, or 250 once they have been used up.
+type smtpServer struct {
+	listener    net.Listener
+	mutex       sync.Mutex
+	rcptReplies []int
+	connections int
+	messages    []string
+}
+
+func newSMTPServer(t *testing.T, rcptReplies ...int) *smtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s := &smtpServer{listener: listener, rcptReplies: rcptReplies}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *smtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	s.mutex.Lock()
+	s.connections++
+	s.mutex.Unlock()
+
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		switch command := strings.ToUpper(strings.Fields(line + " ")[0]); command {
+		case "EHLO", "HELO", "MAIL", "RSET", "NOOP":
+			tp.PrintfLine("250 OK")
+
+		case "RCPT":
+			s.mutex.Lock()
+			reply := 250
+			if len(s.rcptReplies) > 0 {
+				reply, s.rcptReplies = s.rcptReplies[0], s.rcptReplies[1:]
+			}
+
+			s.mutex.Unlock()
+			tp.PrintfLine("%d recipient reply", reply)
+
+		case "DATA":
+			tp.PrintfLine("354 Go ahead")
+			lines, err := tp.ReadDotLines()
+			if err != nil {
+				return
+			}
+
+			s.mutex.Lock()
+			s.messages = append(s.messages, strings.Join(lines, "\n"))
+			s.mutex.Unlock()
+			tp.PrintfLine("250 Accepted")
+
+		case "QUIT":
+			tp.PrintfLine("221 Bye")
+			return
+
+		default:
+			tp.PrintfLine("502 Unsupported")
+		}
+	}
+}
+
+var alert = notify.Alert{
+	ID:       "1",
+	Action:   "repo.destroy",
+	Severity: "critical",
+	Text:     "User *alice* deleted repo *ons/app*.",
+	Fields:   []notify.Field{{Title: "Actor", Value: "User *alice*"}},
+	Links:    []notify.Link{{Text: "View Actor", URL: "https://github.com/alice"}},
+}
+
+func TestNotifySendsMessage(t *testing.T) {
+	server := newSMTPServer(t)
+	defer server.listener.Close()
+
+	n, err := NewNotifier(server.listener.Addr().String(), "", "", "auditor@example.com", []string{"security@example.com", "ops@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := n.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(server.messages) != 1 {
+		t.Fatalf("server accepted %d messages, want 1", len(server.messages))
+	}
+
+	message := server.messages[0]
+	for _, want := range []string{
+		"From: auditor@example.com\n",
+		"To: security@example.com, ops@example.com\n",
+		"Subject: GitHub audit log: repo.destroy (critical)\n",
+		"Content-Type: text/plain; charset=utf-8\n",
+		"\n\nUser alice deleted repo ons/app.\n\nActor: User alice\n\nView Actor: https://github.com/alice",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("message doesn't contain %q:\n%s", want, message)
+		}
+	}
+}
+
+func TestNotifyReturnsRejection(t *testing.T) {
+	server := newSMTPServer(t, 550)
+	defer server.listener.Close()
+
+	n, _ := NewNotifier(server.listener.Addr().String(), "", "", "auditor@example.com", []string{"security@example.com"})
+	err := n.Notify(context.Background(), alert)
+
+	protoErr, ok := errors.Cause(err).(*textproto.Error)
+	if !ok || protoErr.Code != 550 {
+		t.Errorf("error = %v, want a 550 reply", err)
+	}
+
+	if server.connections != 1 || len(server.messages) != 0 {
+		t.Errorf("server received %d connections and accepted %d messages, want 1 connection and no messages", server.connections, len(server.messages))
+	}
+}
+
+func TestNotifyRetriesTransientFailures(t *testing.T) {
+	server := newSMTPServer(t, 451)
+	defer server.listener.Close()
+
+	n, _ := NewNotifier(server.listener.Addr().String(), "", "", "auditor@example.com", []string{"security@example.com"})
+	if err := n.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if server.connections != 2 || len(server.messages) != 1 {
+		t.Errorf("server received %d connections and accepted %d messages, want 2 connections and 1 message", server.connections, len(server.messages))
+	}
+}
+
+func TestSendStopsWhenCancelled(t *testing.T) {
+
+	// The server accepts connections but never sends its greeting.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			defer conn.Close()
+		}
+	}()
+
+	n, err := NewNotifier(listener.Addr().String(), "", "", "auditor@example.com", []string{"security@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	if err := n.send(ctx, "subject", "body"); err != context.Canceled {
+		t.Errorf("error = %v, want %v", err, context.Canceled)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("send took %v after being cancelled", elapsed)
+	}
+}
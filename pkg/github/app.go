@@ -0,0 +1,238 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+
+	// jwtLifetime is how long the JSON Web Tokens used to authenticate as a GitHub App are valid for. GitHub allows up to ten minutes.
+	jwtLifetime = 9 * time.Minute
+
+	// jwtClockSkew is how far into the past JSON Web Tokens are issued, to allow for the clock drifting from GitHub's.
+	jwtClockSkew = 60 * time.Second
+
+	// tokenRefreshMargin is how long before an installation access token expires that it is refreshed.
+	tokenRefreshMargin = 5 * time.Minute
+)
+
+type (
+
+	// TokenSource is implemented by the ways of obtaining a token to authenticate with the GitHub API.
+	TokenSource interface {
+
+		// Token returns a valid token.
+		Token(ctx context.Context) (string, error)
+	}
+
+	// staticToken is a TokenSource that always returns the same token, such as a personal access token.
+	staticToken string
+
+	// App authenticates as a GitHub App, signing JSON Web Tokens with the app's private key to obtain installation access tokens.
+	App struct {
+		id         string
+		key        *rsa.PrivateKey
+		options    options
+		httpClient *http.Client
+		restURL    string
+
+		mutex   sync.Mutex
+		clients map[string]*Client
+	}
+
+	// installationTokenSource is a TokenSource returning access tokens for a GitHub App installation. Tokens are cached and refreshed
+	// shortly before they expire.
+	installationTokenSource struct {
+		app            *App
+		installationID int64
+
+		mutex     sync.Mutex
+		token     string
+		expiresAt time.Time
+	}
+)
+
+// Token returns the static token.
+func (t staticToken) Token(ctx context.Context) (string, error) {
+	return string(t), nil
+}
+
+// NewApp instantiates a GitHub App with the passed ID, authenticating using the passed PEM-encoded private key (as downloaded from the
+// app's settings page). The passed options configure both the app and the clients it returns.
+func NewApp(appID string, privateKey []byte, opts ...Option) (*App, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts)
+	_, restURL := o.endpoints()
+
+	return &App{
+		id:         appID,
+		key:        key,
+		options:    o,
+		httpClient: o.httpClient(),
+		restURL:    restURL,
+		clients:    make(map[string]*Client),
+	}, nil
+}
+
+// InstallationClient returns a client authenticated as the app's installation with the passed ID.
+func (a *App) InstallationClient(installationID int64) *Client {
+	return newClient(&installationTokenSource{app: a, installationID: installationID}, a.options)
+}
+
+// OrganizationClient returns a client authenticated as the app's installation on the passed organisation, looking up the installation
+// the first time each organisation is passed.
+func (a *App) OrganizationClient(ctx context.Context, organisation string) (*Client, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if client, ok := a.clients[organisation]; ok {
+		return client, nil
+	}
+
+	var installation struct {
+		ID int64 `json:"id"`
+	}
+
+	if err := a.request(ctx, http.MethodGet, "orgs/"+url.PathEscape(organisation)+"/installation", &installation); err != nil {
+		return nil, errors.Wrapf(err, "failed to find the GitHub App installation for organisation %s", organisation)
+	}
+
+	client := a.InstallationClient(installation.ID)
+	a.clients[organisation] = client
+	return client, nil
+}
+
+// Token returns an installation access token, requesting a new one if there isn't a cached token or it is about to expire.
+func (s *installationTokenSource) Token(ctx context.Context) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.token) > 0 && time.Until(s.expiresAt) > tokenRefreshMargin {
+		return s.token, nil
+	}
+
+	var res struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	path := fmt.Sprintf("app/installations/%d/access_tokens", s.installationID)
+	if err := s.app.request(ctx, http.MethodPost, path, &res); err != nil {
+		return "", errors.Wrapf(err, "failed to create an access token for GitHub App installation %d", s.installationID)
+	}
+
+	s.token = res.Token
+	s.expiresAt = res.ExpiresAt
+	return s.token, nil
+}
+
+// request makes a REST API request authenticated as the app itself, decoding the JSON response into the passed value.
+func (a *App) request(ctx context.Context, method, path string, v interface{}) error {
+	jwt, err := a.jwt(time.Now())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, a.restURL+"/"+path, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+jwt)
+
+	resp, err := a.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("GitHub API responded with status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// jwt returns a JSON Web Token issued at the passed time identifying the app, signed using RS256 with the app's private key.
+func (a *App) jwt(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-jwtClockSkew).Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+		"iss": a.id,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign GitHub App JSON Web Token")
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parsePrivateKey parses the passed PEM-encoded RSA private key, in either PKCS #1 (as GitHub issues them) or PKCS #8 form.
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode GitHub App private key: no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse GitHub App private key")
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("GitHub App private key isn't an RSA key")
+	}
+
+	return key, nil
+}
@@ -0,0 +1,167 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	return key
+}
+
+func pkcs1PEM(key *rsa.PrivateKey) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+// verifyJWT checks the passed JSON Web Token is signed with the passed key using RS256, returning its claims.
+func verifyJWT(t *testing.T, jwt string, key *rsa.PrivateKey) map[string]interface{} {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("JWT has %d parts, want 3", len(parts))
+	}
+
+	var header map[string]string
+	decodeJWTPart(t, parts[0], &header)
+	if header["alg"] != "RS256" || header["typ"] != "JWT" {
+		t.Errorf("header = %v", header)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("failed to decode signature: %v", err)
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("invalid signature: %v", err)
+	}
+
+	var claims map[string]interface{}
+	decodeJWTPart(t, parts[1], &claims)
+	return claims
+}
+
+func decodeJWTPart(t *testing.T, part string, v interface{}) {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		t.Fatalf("failed to decode JWT part: %v", err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("failed to parse JWT part: %v", err)
+	}
+}
+
+func TestJWT(t *testing.T) {
+	key := newTestKey(t)
+	app, err := NewApp("12345", pkcs1PEM(key))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now := time.Unix(1600000000, 0)
+	jwt, err := app.jwt(now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	claims := verifyJWT(t, jwt, key)
+	if claims["iss"] != "12345" {
+		t.Errorf("iss = %v, want 12345", claims["iss"])
+	}
+
+	if iat := int64(claims["iat"].(float64)); iat != now.Add(-jwtClockSkew).Unix() {
+		t.Errorf("iat = %d, want %d", iat, now.Add(-jwtClockSkew).Unix())
+	}
+
+	// GitHub rejects tokens that expire more than ten minutes after they were issued.
+	if exp := int64(claims["exp"].(float64)); exp != now.Add(jwtLifetime).Unix() || exp-now.Unix() > 600 {
+		t.Errorf("exp = %d, want %d", exp, now.Add(jwtLifetime).Unix())
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key := newTestKey(t)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{"PKCS #1", pkcs1PEM(key), false},
+		{"PKCS #8", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), false},
+		{"not PEM", []byte("not a key"), true},
+		{"not a key", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("junk")}), true},
+	}
+
+	for _, test := range tests {
+		parsed, err := parsePrivateKey(test.data)
+		switch {
+		case test.wantErr && err == nil:
+			t.Errorf("%s: expected an error", test.name)
+		case !test.wantErr && err != nil:
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		case !test.wantErr && parsed.N.Cmp(key.N) != 0:
+			t.Errorf("%s: parsed a different key", test.name)
+		}
+	}
+}
+
+func TestInstallationTokenIsCached(t *testing.T) {
+	key := newTestKey(t)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v3/app/installations/42/access_tokens" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		verifyJWT(t, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), key)
+		fmt.Fprintf(w, `{"token": "token-%d", "expires_at": %q}`, requests, time.Now().Add(time.Hour).Format(time.RFC3339))
+	}))
+
+	defer server.Close()
+
+	app, err := NewApp("12345", pkcs1PEM(key), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	source := &installationTokenSource{app: app, installationID: 42}
+	for i := 0; i < 2; i++ {
+		token, err := source.Token(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if token != "token-1" {
+			t.Errorf("token = %s, want token-1", token)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("made %d requests, want 1", requests)
+	}
+}
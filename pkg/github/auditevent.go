@@ -0,0 +1,270 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type (
+
+	// AuditEvent is an audit log event of a particular type. Each type of audit entry has its own event type holding only the fields
+	// that type of entry has, such as *RepoCreateAuditEntry, generated from pkg/github/schema/actions.json. Events whose action has
+	// no type are of an unknown type, which is described generically and only alerted on if a rule includes it.
+	AuditEvent interface {
+
+		// Node returns the event's fields as a Node, the flat view of every type of event.
+		Node() Node
+
+		// Subject returns the actor who performed the action.
+		Subject() Actor
+
+		// Target returns what the action was performed on as message text, e.g. "repo *ONSdigital/github-auditor*".
+		Target() string
+
+		// Scope returns the team, repository or organisation the action was performed in as message text, e.g. "team *platform*". Events
+		// of the same action in the same scope, such as users added to the same team, can be summarised together.
+		Scope() string
+
+		// Describe returns the alert text for the event, or an empty string if the event isn't alerted on.
+		Describe() string
+
+		// Known returns whether the event's action is one of those described in pkg/github/schema/actions.json.
+		Known() bool
+
+		// Category returns the area of GitHub the event relates to.
+		Category() Category
+
+		// Severity returns how urgently the event needs attention.
+		Severity() Severity
+
+		setDefaultOrganization(organisation string)
+	}
+
+	// unknownEvent is an event whose action has no type, such as a Git or branch protection event from the REST audit log.
+	unknownEvent struct {
+		node Node
+	}
+)
+
+// Subject returns the actor who performed the action.
+func (e AuditEntry) Subject() Actor {
+	return e.Actor
+}
+
+// Scope returns the organisation the action was performed in, or an empty string if the event doesn't name it. Typed events with a team
+// or repository return it instead.
+func (e AuditEntry) Scope() string {
+	if len(e.OrganizationName) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+// Known returns true, as every typed event's action is described in pkg/github/schema/actions.json.
+func (e AuditEntry) Known() bool {
+	return true
+}
+
+// setDefaultOrganization sets the organisation name of the event if it doesn't include it, as the name is needed to route its alert.
+func (e *AuditEntry) setDefaultOrganization(organisation string) {
+	if len(e.OrganizationName) == 0 {
+		e.OrganizationName = organisation
+	}
+}
+
+func (e *unknownEvent) Node() Node {
+	return e.node
+}
+
+func (e *unknownEvent) Subject() Actor {
+	return e.node.Actor
+}
+
+// Target returns the most specific of the repository, team, user or organisation the event has, or an empty string if it has none.
+func (e *unknownEvent) Target() string {
+	n := e.node
+	switch {
+	case len(n.RepositoryName) > 0:
+		return fmt.Sprintf("repo *%s*", n.RepositoryName)
+	case len(n.TeamName) > 0:
+		return fmt.Sprintf("team *%s*", n.TeamName)
+	case len(n.User.Login) > 0:
+		return n.User.Describe(false)
+	case len(n.OrganizationName) > 0:
+		return fmt.Sprintf("organisation *%s*", n.OrganizationName)
+	}
+
+	return ""
+}
+
+// Scope returns the team or repository the event has, or otherwise its organisation, or an empty string if it has none.
+func (e *unknownEvent) Scope() string {
+	n := e.node
+	switch {
+	case len(n.TeamName) > 0:
+		return fmt.Sprintf("team *%s*", n.TeamName)
+	case len(n.RepositoryName) > 0:
+		return fmt.Sprintf("repo *%s*", n.RepositoryName)
+	case len(n.OrganizationName) > 0:
+		return fmt.Sprintf("organisation *%s*", n.OrganizationName)
+	}
+
+	return ""
+}
+
+// Describe returns a generic description of the event naming its action, e.g. "User *octocat* performed *git.push* on repo
+// *ONSdigital/github-auditor*."
+func (e *unknownEvent) Describe() string {
+	text := fmt.Sprintf("performed *%s*", e.node.Action)
+	if actor := e.node.Actor.Describe(true); len(actor) > 0 {
+		text = actor + " " + text
+	} else {
+		text = "An actor " + text
+	}
+
+	if target := e.Target(); len(target) > 0 {
+		text = fmt.Sprintf("%s on %s", text, target)
+	}
+
+	return text + "."
+}
+
+func (e *unknownEvent) Known() bool {
+	return false
+}
+
+func (e *unknownEvent) Category() Category {
+	info, _ := InfoForEvent(e.node.Action)
+	return info.Category
+}
+
+func (e *unknownEvent) Severity() Severity {
+	return Info
+}
+
+func (e *unknownEvent) setDefaultOrganization(organisation string) {
+	if len(e.node.OrganizationName) == 0 {
+		e.node.OrganizationName = organisation
+	}
+}
+
+// EventForNode returns the typed event for the action of the passed node, such as one built from another source of audit events, or an
+// event of unknown type if the action has no type.
+func EventForNode(n Node) AuditEvent {
+	return eventForNode(n)
+}
+
+// decodeAuditEvent decodes the passed GraphQL audit log node into the typed event for its __typename, which keeps the node's JSON.
+func decodeAuditEvent(data []byte) (AuditEvent, error) {
+	var typed struct {
+		Typename string `json:"__typename"`
+	}
+
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, errors.Wrap(err, "failed to parse audit log entry")
+	}
+
+	// Types added to the schema since the event types were generated are decoded as events of unknown type.
+	e := newAuditEvent(typed.Typename, data)
+	if e == nil {
+		n := Node{Raw: data}
+		if err := json.Unmarshal(data, &n); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s audit log entry", typed.Typename)
+		}
+
+		return &unknownEvent{n}, nil
+	}
+
+	if err := json.Unmarshal(data, e); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s audit log entry", typed.Typename)
+	}
+
+	return e, nil
+}
+
+// withDefaultOrganization returns a page function that sets the organisation name of each event that doesn't include it before calling
+// the passed function.
+func withDefaultOrganization(organisation string, fn PageFunc) PageFunc {
+	return func(events []AuditEvent) error {
+		for _, e := range events {
+			e.setDefaultOrganization(organisation)
+		}
+
+		return fn(events)
+	}
+}
+
+// Describe returns the actor as message text, e.g. "user *octocat* (The Octocat)". The actor's type is capitalised if the passed flag
+// is set, for when it starts a sentence.
+func (a Actor) Describe(capitalise bool) string {
+	actorName := ""
+
+	switch a.Type {
+	case "Bot":
+		actorName = fmt.Sprintf("bot *%s*", a.Login)
+		if capitalise {
+			actorName = fmt.Sprintf("Bot *%s*", a.Login)
+		}
+
+	case "Organization":
+		actorName = fmt.Sprintf("org *%s*", a.Name)
+		if capitalise {
+			actorName = fmt.Sprintf("Org *%s*", a.Name)
+		}
+
+	case "User":
+		actorName = fmt.Sprintf("user *%s*", a.Login)
+		if capitalise {
+			actorName = fmt.Sprintf("User *%s*", a.Login)
+		}
+
+		if len(a.Name) > 0 {
+			actorName = fmt.Sprintf("%s (%s)", actorName, a.Name)
+		}
+	}
+
+	return actorName
+}
+
+// The following functions compute the message arguments that aren't a single field, listed in the derived section of
+// pkg/github/schema/actions.json.
+
+func forkingTarget(n Node, capitalise bool) string {
+
+	// Private repo forking can be set for a single repo or for the whole organisation.
+	if len(n.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", n.RepositoryName)
+	}
+
+	return fmt.Sprintf("organisation *%s*", n.OrganizationName)
+}
+
+func invitationPermission(n Node, capitalise bool) string {
+	if n.CanInviteOutsideCollaboratorsToRepositories != nil && *n.CanInviteOutsideCollaboratorsToRepositories {
+		return "allowed members to invite"
+	}
+
+	return "prevented members from inviting"
+}
+
+func repositoryCreation(n Node, capitalise bool) string {
+
+	// The visibility is the kinds of repo members can create, e.g. PUBLIC_PRIVATE, and is only meaningful if they can create repos.
+	if n.CanCreateRepositories != nil && !*n.CanCreateRepositories {
+		return "none"
+	}
+
+	return strings.ToLower(strings.Replace(n.Visibility, "_", " and ", -1))
+}
+
+func userOrEmail(n Node, capitalise bool) string {
+	if len(n.Email) > 0 {
+		return fmt.Sprintf("*%s*", n.Email)
+	}
+
+	return n.User.Describe(capitalise)
+}
@@ -0,0 +1,68 @@
+package github
+
+import "testing"
+
+func TestUnknownEventDescribe(t *testing.T) {
+	user := Actor{Type: "User", Login: "octocat"}
+	tests := []struct {
+		node Node
+		want string
+	}{
+		{
+			node: Node{Action: "git.push", Actor: user, RepositoryName: "ons/app", OrganizationName: "ons"},
+			want: "User *octocat* performed *git.push* on repo *ons/app*.",
+		},
+		{
+			node: Node{Action: "business.add_admin", Actor: user, User: Actor{Type: "User", Login: "bob"}},
+			want: "User *octocat* performed *business.add_admin* on user *bob*.",
+		},
+		{
+			node: Node{Action: "org.config.update", OrganizationName: "ons"},
+			want: "An actor performed *org.config.update* on organisation *ons*.",
+		},
+		{
+			node: Node{Action: "hook.create", Actor: user},
+			want: "User *octocat* performed *hook.create*.",
+		},
+	}
+
+	for _, test := range tests {
+		e := &unknownEvent{test.node}
+		if got := e.Describe(); got != test.want {
+			t.Errorf("%s: Describe = %q, want %q", test.node.Action, got, test.want)
+		}
+
+		if e.Known() {
+			t.Errorf("%s: unknown event is known", test.node.Action)
+		}
+	}
+}
+
+func TestTypedEventIsKnown(t *testing.T) {
+	e := eventForNode(Node{Action: "repo.create", RepositoryName: "ons/app"})
+	if _, ok := e.(*unknownEvent); ok || !e.Known() {
+		t.Error("repo.create event isn't typed")
+	}
+}
+
+func TestScope(t *testing.T) {
+	tests := []struct {
+		node Node
+		want string
+	}{
+		{node: Node{Action: "team.add_member", TeamName: "ons/platform", OrganizationName: "ons"}, want: "team *ons/platform*"},
+		{node: Node{Action: "team.add_repository", TeamName: "ons/platform", RepositoryName: "ons/app"}, want: "team *ons/platform*"},
+		{node: Node{Action: "repo.add_member", RepositoryName: "ons/app", OrganizationName: "ons"}, want: "repo *ons/app*"},
+		{node: Node{Action: "repo.add_member", OrganizationName: "ons"}, want: "organisation *ons*"},
+		{node: Node{Action: "org.add_member", OrganizationName: "ons"}, want: "organisation *ons*"},
+		{node: Node{Action: "org.add_member"}, want: ""},
+		{node: Node{Action: "git.push", RepositoryName: "ons/app", OrganizationName: "ons"}, want: "repo *ons/app*"},
+		{node: Node{Action: "hook.create", OrganizationName: "ons"}, want: "organisation *ons*"},
+	}
+
+	for _, test := range tests {
+		if got := eventForNode(test.node).Scope(); got != test.want {
+			t.Errorf("%s: Scope = %q, want %q", test.node.Action, got, test.want)
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package github
 
 import (
-	"sort"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/ONSdigital/graphql"
 	"github.com/pkg/errors"
@@ -16,27 +19,6 @@ type (
 		Name  string `json:"name,omitempty"`  // Organization or User
 	}
 
-	// Node represents a node in the returned results graph.
-	Node struct {
-		ID                   string `json:"id"`
-		Action               string `json:"action"`
-		Actor                Actor
-		BlockedUser          Actor
-		CreatedAt            string `json:"createdAt"`
-		Email                string `json:"email,omitempty"`
-		MergeType            string `json:"mergeType,omitempty"`
-		OauthApplicationName string `json:"oauthApplicationName,omitempty"`
-		OrganizationName     string `json:"organizationName,omitempty"`
-		ParentTeamName       string `json:"parentTeamName,omitempty"`
-		Permission           string `json:"permission,omitempty"`
-		PermissionWas        string `json:"permissionWas,omitempty"`
-		RepositoryName       string `json:"repositoryName,omitempty"`
-		TeamName             string `json:"teamName,omitempty"`
-		TopicName            string `json:"topicName,omitempty"`
-		User                 Actor
-		Visibility           string `json:"visibility,omitempty"`
-	}
-
 	// PageInfo represents the pagination information returned from the query.
 	PageInfo struct {
 		StartCursor     string
@@ -50,19 +32,68 @@ type (
 		AuditLog struct {
 			TotalCount int
 			PageInfo   PageInfo
-			Nodes      []Node
+			Nodes      []json.RawMessage // Decoded into typed events by their __typename.
 		}
 	}
+
+	// PageFunc is called with each page of audit log events as it is fetched. Returning an error stops any further pages being fetched.
+	PageFunc func(events []AuditEvent) error
 )
 
 // FetchAllAuditEvents returns all audit log events for the passed organisation. The returned logs are sorted by their createdAt timestamp.
-func (c Client) FetchAllAuditEvents(organisation string) (events []Node, err error) {
+// All events are held in memory, so StreamAllAuditEvents should be preferred for large audit logs.
+func (c Client) FetchAllAuditEvents(ctx context.Context, organisation string) (events []AuditEvent, err error) {
+	err = c.StreamAllAuditEvents(ctx, organisation, func(page []AuditEvent) error {
+		events = append(events, page...)
+		return nil
+	})
+
+	return events, err
+}
+
+// FetchAuditEventsSince returns the audit log events for the passed organisation that were created at or after the passed RFC 3339 timestamp.
+// The returned logs are sorted by their createdAt timestamp.
+func (c Client) FetchAuditEventsSince(ctx context.Context, organisation, since string) (events []AuditEvent, err error) {
+	err = c.StreamAuditEventsSince(ctx, organisation, since, func(page []AuditEvent) error {
+		events = append(events, page...)
+		return nil
+	})
+
+	return events, err
+}
+
+// StreamAllAuditEvents calls the passed function with each page of audit log events for the passed organisation as it is fetched.
+// Events are requested in ascending createdAt order, so every event within a page and every page is no older than the one before it.
+func (c Client) StreamAllAuditEvents(ctx context.Context, organisation string, fn PageFunc) error {
+	return c.streamAuditEvents(ctx, organisation, nil, fn)
+}
+
+// StreamAuditEventsSince calls the passed function with each page of audit log events for the passed organisation that were created at
+// or after the passed RFC 3339 timestamp. The same ordering guarantees as StreamAllAuditEvents apply.
+func (c Client) StreamAuditEventsSince(ctx context.Context, organisation, since string, fn PageFunc) error {
+	t, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse high-water mark '%s'", since)
+	}
+
+	// The audit log search syntax only has second precision, so the comparison is inclusive to avoid missing events created in the
+	// same second as the high-water mark. Events that have already been processed are filtered out by the Firestore deduplication.
+	query := fmt.Sprintf("created:>=%s", t.UTC().Format("2006-01-02T15:04:05Z"))
+	return c.streamAuditEvents(ctx, organisation, &query, fn)
+}
+
+func (c Client) streamAuditEvents(ctx context.Context, organisation string, query *string, fn PageFunc) error {
 	var endCursor *string // Using a pointer type allows this to be nil (an empty string isn't a valid cursor).
 
 	req := graphql.NewRequest(`
-		query GitHubAuditEntries($login: String!, $after: String) {
+		query GitHubAuditEntries($login: String!, $after: String, $query: String) {
+			rateLimit {
+				cost
+				remaining
+				resetAt
+			}
 			organization(login: $login) {
-				auditLog(first: 50, after: $after) {
+				auditLog(first: 50, after: $after, query: $query, orderBy: {field: CREATED_AT, direction: ASC}) {
 					totalCount
 					pageInfo {
 						startCursor
@@ -70,330 +101,7 @@ func (c Client) FetchAllAuditEvents(organisation string) (events []Node, err err
 						hasNextPage
 						hasPreviousPage
 					}
-					nodes {
-						... on Node {
-							id
-						}
-						... on AuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							user {
-								...userFields
-							}
-						}
-						... on OauthApplicationCreateAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							oauthApplicationName
-							organizationName
-						}
-						... on OrgAddBillingManagerAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							organizationName
-							user {
-								...userFields
-							}
-						}
-						... on OrgAddMemberAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							organizationName
-							user {
-								...userFields
-							}
-						}
-						... on OrgBlockUserAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							blockedUser {
-								...userFields
-							}
-							createdAt
-							organizationName
-						}
-						... on OrgCreateAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							organizationName
-						}
-						... on OrgDisableSamlAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							organizationName
-						}
-						... on OrgDisableTwoFactorRequirementAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							organizationName
-						}
-						... on OrgEnableOauthAppRestrictionsAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							organizationName
-						}
-						... on OrgEnableSamlAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							organizationName
-						}
-						... on OrgEnableTwoFactorRequirementAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							organizationName
-						}
-						... on OrgInviteMemberAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							email
-							organizationName
-							user {
-								...userFields
-							}
-						}
-						... on OrgOauthAppAccessApprovedAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							oauthApplicationName
-							organizationName
-						}
-						... on OrgOauthAppAccessDeniedAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							oauthApplicationName
-							organizationName
-						}
-						... on OrgOauthAppAccessRequestedAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							oauthApplicationName
-							organizationName
-						}
-						... on OrgRemoveBillingManagerAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							organizationName
-							user {
-								...userFields
-							}
-						}
-						... on OrgRemoveMemberAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							organizationName
-							user {
-								...userFields
-							}
-						}
-						... on OrgRemoveOutsideCollaboratorAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							organizationName
-							user {
-								...userFields
-							}
-						}
-						... on OrgRestoreMemberAuditEntry {
-							action
-							actor {
-							  ...actorFields
-							}
-							createdAt
-							user {
-								...userFields
-							}
-							organizationName
-						  }
-						... on OrgUpdateMemberAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							organizationName
-							permission
-							permissionWas
-							user {
-								...userFields
-							}
-						}
-						... on RepoAccessAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							repositoryName
-							visibility
-						}
-						... on RepoAddMemberAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							repositoryName
-							user {
-								...userFields
-							}
-						}
-						... on RepoAddTopicAuditEntry {
-							action
-							actor {
-							  ...actorFields
-							}
-							createdAt
-							repositoryName
-							topicName
-						}
-						... on RepoArchivedAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							repositoryName
-						}
-						... on RepoChangeMergeSettingAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							mergeType
-							repositoryName
-						}
-						... on RepoCreateAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							repositoryName
-							visibility
-						}
-						... on RepoDestroyAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							repositoryName
-						}
-						... on RepoRemoveMemberAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							repositoryName
-							user {
-								...userFields
-							}
-						}
-						... on TeamAddMemberAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							teamName
-							user {
-								...userFields
-							}
-						}
-						... on TeamAddRepositoryAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							repositoryName
-							teamName
-						}
-						... on TeamChangeParentTeamAuditEntry {
-							action
-							actor {
-							  ...actorFields
-							}
-							createdAt
-							parentTeamName
-							teamName
-						}
-						... on TeamRemoveMemberAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							teamName
-							user {
-								...userFields
-							}
-						}
-						... on TeamRemoveRepositoryAuditEntry {
-							action
-							actor {
-								...actorFields
-							}
-							createdAt
-							repositoryName
-							teamName
-						}
-					}
+					nodes {` + auditEntrySelection + `}
 				}
 			}
 		}
@@ -417,28 +125,45 @@ func (c Client) FetchAllAuditEvents(organisation string) (events []Node, err err
 	`)
 
 	req.Var("login", organisation)
+	req.Var("query", query)
+	fn = withDefaultOrganization(organisation, fn)
 
 	page := 0
 	hasNextPage := true
-	var nodes []Node
 
 	for hasNextPage {
 		page++
-		res := &struct{ Organization Organization }{}
+		res := &struct {
+			RateLimit    RateLimit
+			Organization Organization
+		}{}
 		req.Var("after", endCursor)
 
-		if err := c.Run(req, &res); err != nil {
-			return nil, errors.Wrap(err, "failed to fetch audit log entries for organisation")
+		if err := c.Run(ctx, req, &res); err != nil {
+			return errors.Wrapf(err, "failed to fetch page %d of audit log entries for organisation", page)
+		}
+
+		events := make([]AuditEvent, len(res.Organization.AuditLog.Nodes))
+		for i, node := range res.Organization.AuditLog.Nodes {
+			var err error
+			if events[i], err = decodeAuditEvent(node); err != nil {
+				return errors.Wrapf(err, "failed to decode page %d of audit log entries for organisation", page)
+			}
+		}
+
+		if err := fn(events); err != nil {
+			return errors.Wrapf(err, "failed to process page %d of audit log entries for organisation", page)
 		}
 
-		nodes = append(nodes, res.Organization.AuditLog.Nodes...)
 		endCursor = &res.Organization.AuditLog.PageInfo.EndCursor
 		hasNextPage = res.Organization.AuditLog.PageInfo.HasNextPage
-	}
 
-	sort.SliceStable(nodes, func(i, j int) bool {
-		return nodes[i].CreatedAt < nodes[j].CreatedAt
-	})
+		if hasNextPage {
+			if err := waitForRateLimit(ctx, res.RateLimit); err != nil {
+				return err
+			}
+		}
+	}
 
-	return nodes, nil
+	return nil
 }
@@ -0,0 +1,2796 @@
+// Code generated by genauditlog from schema/auditlog.graphql and schema/actions.json. DO NOT EDIT.
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// auditEntrySelection selects the type and fields of every type of audit entry in an organisation's audit log.
+const auditEntrySelection = `
+						__typename
+						... on AuditEntry {
+							action
+							actor {
+								...actorFields
+							}
+							createdAt
+							user {
+								...userFields
+							}
+						}
+						... on EnterpriseAuditEntryData {
+							enterpriseSlug
+						}
+						... on Node {
+							id
+						}
+						... on OauthApplicationAuditEntryData {
+							oauthApplicationName
+						}
+						... on OrganizationAuditEntryData {
+							organizationName
+						}
+						... on RepositoryAuditEntryData {
+							repositoryName
+						}
+						... on TeamAuditEntryData {
+							teamName
+						}
+						... on TopicAuditEntryData {
+							topicName
+						}
+						... on OrgAddBillingManagerAuditEntry {
+							email: invitationEmail
+						}
+						... on OrgAddMemberAuditEntry {
+							permission
+						}
+						... on OrgBlockUserAuditEntry {
+							blockedUser {
+								...userFields
+							}
+						}
+						... on OrgCreateAuditEntry {
+							billingPlan
+						}
+						... on OrgInviteMemberAuditEntry {
+							email
+						}
+						... on OrgRemoveBillingManagerAuditEntry {
+							reason
+						}
+						... on OrgRemoveMemberAuditEntry {
+							membershipTypes
+							reason
+						}
+						... on OrgRemoveOutsideCollaboratorAuditEntry {
+							membershipTypes
+							reason
+						}
+						... on OrgUnblockUserAuditEntry {
+							blockedUser {
+								...userFields
+							}
+						}
+						... on OrgUpdateDefaultRepositoryPermissionAuditEntry {
+							permission
+							permissionWas
+						}
+						... on OrgUpdateMemberAuditEntry {
+							permission
+							permissionWas
+						}
+						... on OrgUpdateMemberRepositoryCreationPermissionAuditEntry {
+							canCreateRepositories
+							visibility
+						}
+						... on OrgUpdateMemberRepositoryInvitationPermissionAuditEntry {
+							canInviteOutsideCollaboratorsToRepositories
+						}
+						... on RepoAccessAuditEntry {
+							visibility
+						}
+						... on RepoAddMemberAuditEntry {
+							visibility
+						}
+						... on RepoArchivedAuditEntry {
+							visibility
+						}
+						... on RepoChangeMergeSettingAuditEntry {
+							isEnabled
+							mergeType
+						}
+						... on RepoCreateAuditEntry {
+							forkParentName
+							forkSourceName
+							visibility
+						}
+						... on RepoDestroyAuditEntry {
+							visibility
+						}
+						... on RepoRemoveMemberAuditEntry {
+							visibility
+						}
+						... on TeamChangeParentTeamAuditEntry {
+							parentTeamName
+							parentTeamNameWas
+						}
+`
+
+type (
+
+	// Node represents a node in the returned results graph. Fields that don't exist on the node's type of audit entry are left empty.
+	Node struct {
+		ID                                          string `json:"id"`
+		Action                                      string `json:"action"`
+		Actor                                       Actor
+		BillingPlan                                 string `json:"billingPlan,omitempty"`
+		BlockedUser                                 Actor
+		CanCreateRepositories                       *bool    `json:"canCreateRepositories,omitempty"`
+		CanInviteOutsideCollaboratorsToRepositories *bool    `json:"canInviteOutsideCollaboratorsToRepositories,omitempty"`
+		CreatedAt                                   string   `json:"createdAt"`
+		Email                                       string   `json:"email,omitempty"`
+		EnterpriseSlug                              string   `json:"enterpriseSlug,omitempty"`
+		ForkParentName                              string   `json:"forkParentName,omitempty"`
+		ForkSourceName                              string   `json:"forkSourceName,omitempty"`
+		IsEnabled                                   *bool    `json:"isEnabled,omitempty"`
+		MembershipTypes                             []string `json:"membershipTypes,omitempty"`
+		MergeType                                   string   `json:"mergeType,omitempty"`
+		OauthApplicationName                        string   `json:"oauthApplicationName,omitempty"`
+		OrganizationName                            string   `json:"organizationName,omitempty"`
+		ParentTeamName                              string   `json:"parentTeamName,omitempty"`
+		ParentTeamNameWas                           string   `json:"parentTeamNameWas,omitempty"`
+		Permission                                  string   `json:"permission,omitempty"`
+		PermissionWas                               string   `json:"permissionWas,omitempty"`
+		Reason                                      string   `json:"reason,omitempty"`
+		RepositoryName                              string   `json:"repositoryName,omitempty"`
+		TeamName                                    string   `json:"teamName,omitempty"`
+		TopicName                                   string   `json:"topicName,omitempty"`
+		User                                        Actor
+		Visibility                                  string          `json:"visibility,omitempty"`
+		Raw                                         json.RawMessage `json:"-"` // The JSON object GitHub sent for the event, with every field it included.
+	}
+
+	// AuditEntry holds the fields every type of audit entry has. It is embedded in each typed event.
+	AuditEntry struct {
+		ID               string `json:"id"`
+		Action           string `json:"action"`
+		Actor            Actor
+		CreatedAt        string `json:"createdAt"`
+		OrganizationName string `json:"organizationName,omitempty"`
+		User             Actor
+		Raw              json.RawMessage `json:"-"` // The JSON object GitHub sent for the event, with every field it included.
+	}
+
+	// MembersCanDeleteReposClearAuditEntry is a members_can_delete_repos.clear event.
+	MembersCanDeleteReposClearAuditEntry struct {
+		AuditEntry
+		EnterpriseSlug string `json:"enterpriseSlug,omitempty"`
+	}
+
+	// MembersCanDeleteReposDisableAuditEntry is a members_can_delete_repos.disable event.
+	MembersCanDeleteReposDisableAuditEntry struct {
+		AuditEntry
+		EnterpriseSlug string `json:"enterpriseSlug,omitempty"`
+	}
+
+	// MembersCanDeleteReposEnableAuditEntry is a members_can_delete_repos.enable event.
+	MembersCanDeleteReposEnableAuditEntry struct {
+		AuditEntry
+		EnterpriseSlug string `json:"enterpriseSlug,omitempty"`
+	}
+
+	// OauthApplicationCreateAuditEntry is a oauth_application.create event.
+	OauthApplicationCreateAuditEntry struct {
+		AuditEntry
+		OauthApplicationName string `json:"oauthApplicationName,omitempty"`
+	}
+
+	// OrgAddBillingManagerAuditEntry is a org.add_billing_manager event.
+	OrgAddBillingManagerAuditEntry struct {
+		AuditEntry
+		Email string `json:"email,omitempty"`
+	}
+
+	// OrgAddMemberAuditEntry is a org.add_member event.
+	OrgAddMemberAuditEntry struct {
+		AuditEntry
+		Permission string `json:"permission,omitempty"`
+	}
+
+	// OrgBlockUserAuditEntry is a org.block_user event.
+	OrgBlockUserAuditEntry struct {
+		AuditEntry
+		BlockedUser Actor
+	}
+
+	// OrgConfigDisableCollaboratorsOnlyAuditEntry is a org.config.disable_collaborators_only event.
+	OrgConfigDisableCollaboratorsOnlyAuditEntry struct {
+		AuditEntry
+	}
+
+	// OrgConfigEnableCollaboratorsOnlyAuditEntry is a org.config.enable_collaborators_only event.
+	OrgConfigEnableCollaboratorsOnlyAuditEntry struct {
+		AuditEntry
+	}
+
+	// OrgCreateAuditEntry is a org.create event.
+	OrgCreateAuditEntry struct {
+		AuditEntry
+		BillingPlan string `json:"billingPlan,omitempty"`
+	}
+
+	// OrgDisableOauthAppRestrictionsAuditEntry is a org.disable_oauth_app_restrictions event.
+	OrgDisableOauthAppRestrictionsAuditEntry struct {
+		AuditEntry
+	}
+
+	// OrgDisableSamlAuditEntry is a org.disable_saml event.
+	OrgDisableSamlAuditEntry struct {
+		AuditEntry
+	}
+
+	// OrgDisableTwoFactorRequirementAuditEntry is a org.disable_two_factor_requirement event.
+	OrgDisableTwoFactorRequirementAuditEntry struct {
+		AuditEntry
+	}
+
+	// OrgEnableOauthAppRestrictionsAuditEntry is a org.enable_oauth_app_restrictions event.
+	OrgEnableOauthAppRestrictionsAuditEntry struct {
+		AuditEntry
+	}
+
+	// OrgEnableSamlAuditEntry is a org.enable_saml event.
+	OrgEnableSamlAuditEntry struct {
+		AuditEntry
+	}
+
+	// OrgEnableTwoFactorRequirementAuditEntry is a org.enable_two_factor_requirement event.
+	OrgEnableTwoFactorRequirementAuditEntry struct {
+		AuditEntry
+	}
+
+	// OrgInviteMemberAuditEntry is a org.invite_member event.
+	OrgInviteMemberAuditEntry struct {
+		AuditEntry
+		Email string `json:"email,omitempty"`
+	}
+
+	// OrgInviteToBusinessAuditEntry is a org.invite_to_business event.
+	OrgInviteToBusinessAuditEntry struct {
+		AuditEntry
+		EnterpriseSlug string `json:"enterpriseSlug,omitempty"`
+	}
+
+	// OrgOauthAppAccessApprovedAuditEntry is a org.oauth_app_access_approved event.
+	OrgOauthAppAccessApprovedAuditEntry struct {
+		AuditEntry
+		OauthApplicationName string `json:"oauthApplicationName,omitempty"`
+	}
+
+	// OrgOauthAppAccessDeniedAuditEntry is a org.oauth_app_access_denied event.
+	OrgOauthAppAccessDeniedAuditEntry struct {
+		AuditEntry
+		OauthApplicationName string `json:"oauthApplicationName,omitempty"`
+	}
+
+	// OrgOauthAppAccessRequestedAuditEntry is a org.oauth_app_access_requested event.
+	OrgOauthAppAccessRequestedAuditEntry struct {
+		AuditEntry
+		OauthApplicationName string `json:"oauthApplicationName,omitempty"`
+	}
+
+	// OrgRemoveBillingManagerAuditEntry is a org.remove_billing_manager event.
+	OrgRemoveBillingManagerAuditEntry struct {
+		AuditEntry
+		Reason string `json:"reason,omitempty"`
+	}
+
+	// OrgRemoveMemberAuditEntry is a org.remove_member event.
+	OrgRemoveMemberAuditEntry struct {
+		AuditEntry
+		MembershipTypes []string `json:"membershipTypes,omitempty"`
+		Reason          string   `json:"reason,omitempty"`
+	}
+
+	// OrgRemoveOutsideCollaboratorAuditEntry is a org.remove_outside_collaborator event.
+	OrgRemoveOutsideCollaboratorAuditEntry struct {
+		AuditEntry
+		MembershipTypes []string `json:"membershipTypes,omitempty"`
+		Reason          string   `json:"reason,omitempty"`
+	}
+
+	// OrgRestoreMemberAuditEntry is a org.restore_member event.
+	OrgRestoreMemberAuditEntry struct {
+		AuditEntry
+	}
+
+	// OrgUnblockUserAuditEntry is a org.unblock_user event.
+	OrgUnblockUserAuditEntry struct {
+		AuditEntry
+		BlockedUser Actor
+	}
+
+	// OrgUpdateDefaultRepositoryPermissionAuditEntry is a org.update_default_repository_permission event.
+	OrgUpdateDefaultRepositoryPermissionAuditEntry struct {
+		AuditEntry
+		Permission    string `json:"permission,omitempty"`
+		PermissionWas string `json:"permissionWas,omitempty"`
+	}
+
+	// OrgUpdateMemberAuditEntry is a org.update_member event.
+	OrgUpdateMemberAuditEntry struct {
+		AuditEntry
+		Permission    string `json:"permission,omitempty"`
+		PermissionWas string `json:"permissionWas,omitempty"`
+	}
+
+	// OrgUpdateMemberRepositoryCreationPermissionAuditEntry is a org.update_member_repository_creation_permission event.
+	OrgUpdateMemberRepositoryCreationPermissionAuditEntry struct {
+		AuditEntry
+		CanCreateRepositories *bool  `json:"canCreateRepositories,omitempty"`
+		Visibility            string `json:"visibility,omitempty"`
+	}
+
+	// OrgUpdateMemberRepositoryInvitationPermissionAuditEntry is a org.update_member_repository_invitation_permission event.
+	OrgUpdateMemberRepositoryInvitationPermissionAuditEntry struct {
+		AuditEntry
+		CanInviteOutsideCollaboratorsToRepositories *bool `json:"canInviteOutsideCollaboratorsToRepositories,omitempty"`
+	}
+
+	// PrivateRepositoryForkingDisableAuditEntry is a private_repository_forking.disable event.
+	PrivateRepositoryForkingDisableAuditEntry struct {
+		AuditEntry
+		EnterpriseSlug string `json:"enterpriseSlug,omitempty"`
+		RepositoryName string `json:"repositoryName,omitempty"`
+	}
+
+	// PrivateRepositoryForkingEnableAuditEntry is a private_repository_forking.enable event.
+	PrivateRepositoryForkingEnableAuditEntry struct {
+		AuditEntry
+		EnterpriseSlug string `json:"enterpriseSlug,omitempty"`
+		RepositoryName string `json:"repositoryName,omitempty"`
+	}
+
+	// RepoAccessAuditEntry is a repo.access event.
+	RepoAccessAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+		Visibility     string `json:"visibility,omitempty"`
+	}
+
+	// RepoAddMemberAuditEntry is a repo.add_member event.
+	RepoAddMemberAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+		Visibility     string `json:"visibility,omitempty"`
+	}
+
+	// RepoAddTopicAuditEntry is a repo.add_topic event.
+	RepoAddTopicAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+		TopicName      string `json:"topicName,omitempty"`
+	}
+
+	// RepoArchivedAuditEntry is a repo.archived event.
+	RepoArchivedAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+		Visibility     string `json:"visibility,omitempty"`
+	}
+
+	// RepoChangeMergeSettingAuditEntry is a repo.change_merge_setting event.
+	RepoChangeMergeSettingAuditEntry struct {
+		AuditEntry
+		IsEnabled      *bool  `json:"isEnabled,omitempty"`
+		MergeType      string `json:"mergeType,omitempty"`
+		RepositoryName string `json:"repositoryName,omitempty"`
+	}
+
+	// RepoConfigDisableAnonymousGitAccessAuditEntry is a repo.config.disable_anonymous_git_access event.
+	RepoConfigDisableAnonymousGitAccessAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+	}
+
+	// RepoConfigDisableCollaboratorsOnlyAuditEntry is a repo.config.disable_collaborators_only event.
+	RepoConfigDisableCollaboratorsOnlyAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+	}
+
+	// RepoConfigDisableContributorsOnlyAuditEntry is a repo.config.disable_contributors_only event.
+	RepoConfigDisableContributorsOnlyAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+	}
+
+	// RepoConfigDisableSockpuppetDisallowedAuditEntry is a repo.config.disable_sockpuppet_disallowed event.
+	RepoConfigDisableSockpuppetDisallowedAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+	}
+
+	// RepoConfigEnableAnonymousGitAccessAuditEntry is a repo.config.enable_anonymous_git_access event.
+	RepoConfigEnableAnonymousGitAccessAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+	}
+
+	// RepoConfigEnableCollaboratorsOnlyAuditEntry is a repo.config.enable_collaborators_only event.
+	RepoConfigEnableCollaboratorsOnlyAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+	}
+
+	// RepoConfigEnableContributorsOnlyAuditEntry is a repo.config.enable_contributors_only event.
+	RepoConfigEnableContributorsOnlyAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+	}
+
+	// RepoConfigEnableSockpuppetDisallowedAuditEntry is a repo.config.enable_sockpuppet_disallowed event.
+	RepoConfigEnableSockpuppetDisallowedAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+	}
+
+	// RepoConfigLockAnonymousGitAccessAuditEntry is a repo.config.lock_anonymous_git_access event.
+	RepoConfigLockAnonymousGitAccessAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+	}
+
+	// RepoConfigUnlockAnonymousGitAccessAuditEntry is a repo.config.unlock_anonymous_git_access event.
+	RepoConfigUnlockAnonymousGitAccessAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+	}
+
+	// RepoCreateAuditEntry is a repo.create event.
+	RepoCreateAuditEntry struct {
+		AuditEntry
+		ForkParentName string `json:"forkParentName,omitempty"`
+		ForkSourceName string `json:"forkSourceName,omitempty"`
+		RepositoryName string `json:"repositoryName,omitempty"`
+		Visibility     string `json:"visibility,omitempty"`
+	}
+
+	// RepoDestroyAuditEntry is a repo.destroy event.
+	RepoDestroyAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+		Visibility     string `json:"visibility,omitempty"`
+	}
+
+	// RepoRemoveMemberAuditEntry is a repo.remove_member event.
+	RepoRemoveMemberAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+		Visibility     string `json:"visibility,omitempty"`
+	}
+
+	// RepoRemoveTopicAuditEntry is a repo.remove_topic event.
+	RepoRemoveTopicAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+		TopicName      string `json:"topicName,omitempty"`
+	}
+
+	// RepositoryVisibilityChangeDisableAuditEntry is a repository_visibility_change.disable event.
+	RepositoryVisibilityChangeDisableAuditEntry struct {
+		AuditEntry
+		EnterpriseSlug string `json:"enterpriseSlug,omitempty"`
+	}
+
+	// RepositoryVisibilityChangeEnableAuditEntry is a repository_visibility_change.enable event.
+	RepositoryVisibilityChangeEnableAuditEntry struct {
+		AuditEntry
+		EnterpriseSlug string `json:"enterpriseSlug,omitempty"`
+	}
+
+	// TeamAddMemberAuditEntry is a team.add_member event.
+	TeamAddMemberAuditEntry struct {
+		AuditEntry
+		TeamName string `json:"teamName,omitempty"`
+	}
+
+	// TeamAddRepositoryAuditEntry is a team.add_repository event.
+	TeamAddRepositoryAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+		TeamName       string `json:"teamName,omitempty"`
+	}
+
+	// TeamChangeParentTeamAuditEntry is a team.change_parent_team event.
+	TeamChangeParentTeamAuditEntry struct {
+		AuditEntry
+		ParentTeamName    string `json:"parentTeamName,omitempty"`
+		ParentTeamNameWas string `json:"parentTeamNameWas,omitempty"`
+		TeamName          string `json:"teamName,omitempty"`
+	}
+
+	// TeamRemoveMemberAuditEntry is a team.remove_member event.
+	TeamRemoveMemberAuditEntry struct {
+		AuditEntry
+		TeamName string `json:"teamName,omitempty"`
+	}
+
+	// TeamRemoveRepositoryAuditEntry is a team.remove_repository event.
+	TeamRemoveRepositoryAuditEntry struct {
+		AuditEntry
+		RepositoryName string `json:"repositoryName,omitempty"`
+		TeamName       string `json:"teamName,omitempty"`
+	}
+)
+
+// newAuditEvent returns a typed event for the passed GraphQL type name holding only the passed raw JSON, which is then decoded into
+// it, or nil if the type isn't known.
+func newAuditEvent(typename string, raw json.RawMessage) AuditEvent {
+	switch typename {
+	case "MembersCanDeleteReposClearAuditEntry":
+		return &MembersCanDeleteReposClearAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "MembersCanDeleteReposDisableAuditEntry":
+		return &MembersCanDeleteReposDisableAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "MembersCanDeleteReposEnableAuditEntry":
+		return &MembersCanDeleteReposEnableAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OauthApplicationCreateAuditEntry":
+		return &OauthApplicationCreateAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgAddBillingManagerAuditEntry":
+		return &OrgAddBillingManagerAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgAddMemberAuditEntry":
+		return &OrgAddMemberAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgBlockUserAuditEntry":
+		return &OrgBlockUserAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgConfigDisableCollaboratorsOnlyAuditEntry":
+		return &OrgConfigDisableCollaboratorsOnlyAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgConfigEnableCollaboratorsOnlyAuditEntry":
+		return &OrgConfigEnableCollaboratorsOnlyAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgCreateAuditEntry":
+		return &OrgCreateAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgDisableOauthAppRestrictionsAuditEntry":
+		return &OrgDisableOauthAppRestrictionsAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgDisableSamlAuditEntry":
+		return &OrgDisableSamlAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgDisableTwoFactorRequirementAuditEntry":
+		return &OrgDisableTwoFactorRequirementAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgEnableOauthAppRestrictionsAuditEntry":
+		return &OrgEnableOauthAppRestrictionsAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgEnableSamlAuditEntry":
+		return &OrgEnableSamlAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgEnableTwoFactorRequirementAuditEntry":
+		return &OrgEnableTwoFactorRequirementAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgInviteMemberAuditEntry":
+		return &OrgInviteMemberAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgInviteToBusinessAuditEntry":
+		return &OrgInviteToBusinessAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgOauthAppAccessApprovedAuditEntry":
+		return &OrgOauthAppAccessApprovedAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgOauthAppAccessDeniedAuditEntry":
+		return &OrgOauthAppAccessDeniedAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgOauthAppAccessRequestedAuditEntry":
+		return &OrgOauthAppAccessRequestedAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgRemoveBillingManagerAuditEntry":
+		return &OrgRemoveBillingManagerAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgRemoveMemberAuditEntry":
+		return &OrgRemoveMemberAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgRemoveOutsideCollaboratorAuditEntry":
+		return &OrgRemoveOutsideCollaboratorAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgRestoreMemberAuditEntry":
+		return &OrgRestoreMemberAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgUnblockUserAuditEntry":
+		return &OrgUnblockUserAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgUpdateDefaultRepositoryPermissionAuditEntry":
+		return &OrgUpdateDefaultRepositoryPermissionAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgUpdateMemberAuditEntry":
+		return &OrgUpdateMemberAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgUpdateMemberRepositoryCreationPermissionAuditEntry":
+		return &OrgUpdateMemberRepositoryCreationPermissionAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "OrgUpdateMemberRepositoryInvitationPermissionAuditEntry":
+		return &OrgUpdateMemberRepositoryInvitationPermissionAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "PrivateRepositoryForkingDisableAuditEntry":
+		return &PrivateRepositoryForkingDisableAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "PrivateRepositoryForkingEnableAuditEntry":
+		return &PrivateRepositoryForkingEnableAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoAccessAuditEntry":
+		return &RepoAccessAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoAddMemberAuditEntry":
+		return &RepoAddMemberAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoAddTopicAuditEntry":
+		return &RepoAddTopicAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoArchivedAuditEntry":
+		return &RepoArchivedAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoChangeMergeSettingAuditEntry":
+		return &RepoChangeMergeSettingAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoConfigDisableAnonymousGitAccessAuditEntry":
+		return &RepoConfigDisableAnonymousGitAccessAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoConfigDisableCollaboratorsOnlyAuditEntry":
+		return &RepoConfigDisableCollaboratorsOnlyAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoConfigDisableContributorsOnlyAuditEntry":
+		return &RepoConfigDisableContributorsOnlyAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoConfigDisableSockpuppetDisallowedAuditEntry":
+		return &RepoConfigDisableSockpuppetDisallowedAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoConfigEnableAnonymousGitAccessAuditEntry":
+		return &RepoConfigEnableAnonymousGitAccessAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoConfigEnableCollaboratorsOnlyAuditEntry":
+		return &RepoConfigEnableCollaboratorsOnlyAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoConfigEnableContributorsOnlyAuditEntry":
+		return &RepoConfigEnableContributorsOnlyAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoConfigEnableSockpuppetDisallowedAuditEntry":
+		return &RepoConfigEnableSockpuppetDisallowedAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoConfigLockAnonymousGitAccessAuditEntry":
+		return &RepoConfigLockAnonymousGitAccessAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoConfigUnlockAnonymousGitAccessAuditEntry":
+		return &RepoConfigUnlockAnonymousGitAccessAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoCreateAuditEntry":
+		return &RepoCreateAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoDestroyAuditEntry":
+		return &RepoDestroyAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoRemoveMemberAuditEntry":
+		return &RepoRemoveMemberAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepoRemoveTopicAuditEntry":
+		return &RepoRemoveTopicAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepositoryVisibilityChangeDisableAuditEntry":
+		return &RepositoryVisibilityChangeDisableAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "RepositoryVisibilityChangeEnableAuditEntry":
+		return &RepositoryVisibilityChangeEnableAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "TeamAddMemberAuditEntry":
+		return &TeamAddMemberAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "TeamAddRepositoryAuditEntry":
+		return &TeamAddRepositoryAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "TeamChangeParentTeamAuditEntry":
+		return &TeamChangeParentTeamAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "TeamRemoveMemberAuditEntry":
+		return &TeamRemoveMemberAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	case "TeamRemoveRepositoryAuditEntry":
+		return &TeamRemoveRepositoryAuditEntry{AuditEntry: AuditEntry{Raw: raw}}
+	}
+
+	return nil
+}
+
+// eventForNode returns the typed event for the action of the passed node, which has been normalised from the REST audit log or a
+// webhook. Unknown actions return an event of unknown type.
+func eventForNode(n Node) AuditEvent {
+	switch n.Action {
+	case "members_can_delete_repos.clear":
+		return &MembersCanDeleteReposClearAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			EnterpriseSlug: n.EnterpriseSlug,
+		}
+	case "members_can_delete_repos.disable":
+		return &MembersCanDeleteReposDisableAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			EnterpriseSlug: n.EnterpriseSlug,
+		}
+	case "members_can_delete_repos.enable":
+		return &MembersCanDeleteReposEnableAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			EnterpriseSlug: n.EnterpriseSlug,
+		}
+	case "oauth_application.create":
+		return &OauthApplicationCreateAuditEntry{
+			AuditEntry:           auditEntryOf(n),
+			OauthApplicationName: n.OauthApplicationName,
+		}
+	case "org.add_billing_manager":
+		return &OrgAddBillingManagerAuditEntry{
+			AuditEntry: auditEntryOf(n),
+			Email:      n.Email,
+		}
+	case "org.add_member":
+		return &OrgAddMemberAuditEntry{
+			AuditEntry: auditEntryOf(n),
+			Permission: n.Permission,
+		}
+	case "org.block_user":
+		return &OrgBlockUserAuditEntry{
+			AuditEntry:  auditEntryOf(n),
+			BlockedUser: n.BlockedUser,
+		}
+	case "org.config.disable_collaborators_only":
+		return &OrgConfigDisableCollaboratorsOnlyAuditEntry{
+			AuditEntry: auditEntryOf(n),
+		}
+	case "org.config.enable_collaborators_only":
+		return &OrgConfigEnableCollaboratorsOnlyAuditEntry{
+			AuditEntry: auditEntryOf(n),
+		}
+	case "org.create":
+		return &OrgCreateAuditEntry{
+			AuditEntry:  auditEntryOf(n),
+			BillingPlan: n.BillingPlan,
+		}
+	case "org.disable_oauth_app_restrictions":
+		return &OrgDisableOauthAppRestrictionsAuditEntry{
+			AuditEntry: auditEntryOf(n),
+		}
+	case "org.disable_saml":
+		return &OrgDisableSamlAuditEntry{
+			AuditEntry: auditEntryOf(n),
+		}
+	case "org.disable_two_factor_requirement":
+		return &OrgDisableTwoFactorRequirementAuditEntry{
+			AuditEntry: auditEntryOf(n),
+		}
+	case "org.enable_oauth_app_restrictions":
+		return &OrgEnableOauthAppRestrictionsAuditEntry{
+			AuditEntry: auditEntryOf(n),
+		}
+	case "org.enable_saml":
+		return &OrgEnableSamlAuditEntry{
+			AuditEntry: auditEntryOf(n),
+		}
+	case "org.enable_two_factor_requirement":
+		return &OrgEnableTwoFactorRequirementAuditEntry{
+			AuditEntry: auditEntryOf(n),
+		}
+	case "org.invite_member":
+		return &OrgInviteMemberAuditEntry{
+			AuditEntry: auditEntryOf(n),
+			Email:      n.Email,
+		}
+	case "org.invite_to_business":
+		return &OrgInviteToBusinessAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			EnterpriseSlug: n.EnterpriseSlug,
+		}
+	case "org.oauth_app_access_approved":
+		return &OrgOauthAppAccessApprovedAuditEntry{
+			AuditEntry:           auditEntryOf(n),
+			OauthApplicationName: n.OauthApplicationName,
+		}
+	case "org.oauth_app_access_denied":
+		return &OrgOauthAppAccessDeniedAuditEntry{
+			AuditEntry:           auditEntryOf(n),
+			OauthApplicationName: n.OauthApplicationName,
+		}
+	case "org.oauth_app_access_requested":
+		return &OrgOauthAppAccessRequestedAuditEntry{
+			AuditEntry:           auditEntryOf(n),
+			OauthApplicationName: n.OauthApplicationName,
+		}
+	case "org.remove_billing_manager":
+		return &OrgRemoveBillingManagerAuditEntry{
+			AuditEntry: auditEntryOf(n),
+			Reason:     n.Reason,
+		}
+	case "org.remove_member":
+		return &OrgRemoveMemberAuditEntry{
+			AuditEntry:      auditEntryOf(n),
+			MembershipTypes: n.MembershipTypes,
+			Reason:          n.Reason,
+		}
+	case "org.remove_outside_collaborator":
+		return &OrgRemoveOutsideCollaboratorAuditEntry{
+			AuditEntry:      auditEntryOf(n),
+			MembershipTypes: n.MembershipTypes,
+			Reason:          n.Reason,
+		}
+	case "org.restore_member":
+		return &OrgRestoreMemberAuditEntry{
+			AuditEntry: auditEntryOf(n),
+		}
+	case "org.unblock_user":
+		return &OrgUnblockUserAuditEntry{
+			AuditEntry:  auditEntryOf(n),
+			BlockedUser: n.BlockedUser,
+		}
+	case "org.update_default_repository_permission":
+		return &OrgUpdateDefaultRepositoryPermissionAuditEntry{
+			AuditEntry:    auditEntryOf(n),
+			Permission:    n.Permission,
+			PermissionWas: n.PermissionWas,
+		}
+	case "org.update_member":
+		return &OrgUpdateMemberAuditEntry{
+			AuditEntry:    auditEntryOf(n),
+			Permission:    n.Permission,
+			PermissionWas: n.PermissionWas,
+		}
+	case "org.update_member_repository_creation_permission":
+		return &OrgUpdateMemberRepositoryCreationPermissionAuditEntry{
+			AuditEntry:            auditEntryOf(n),
+			CanCreateRepositories: n.CanCreateRepositories,
+			Visibility:            n.Visibility,
+		}
+	case "org.update_member_repository_invitation_permission":
+		return &OrgUpdateMemberRepositoryInvitationPermissionAuditEntry{
+			AuditEntry: auditEntryOf(n),
+			CanInviteOutsideCollaboratorsToRepositories: n.CanInviteOutsideCollaboratorsToRepositories,
+		}
+	case "private_repository_forking.disable":
+		return &PrivateRepositoryForkingDisableAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			EnterpriseSlug: n.EnterpriseSlug,
+			RepositoryName: n.RepositoryName,
+		}
+	case "private_repository_forking.enable":
+		return &PrivateRepositoryForkingEnableAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			EnterpriseSlug: n.EnterpriseSlug,
+			RepositoryName: n.RepositoryName,
+		}
+	case "repo.access":
+		return &RepoAccessAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+			Visibility:     n.Visibility,
+		}
+	case "repo.add_member":
+		return &RepoAddMemberAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+			Visibility:     n.Visibility,
+		}
+	case "repo.add_topic":
+		return &RepoAddTopicAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+			TopicName:      n.TopicName,
+		}
+	case "repo.archived":
+		return &RepoArchivedAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+			Visibility:     n.Visibility,
+		}
+	case "repo.change_merge_setting":
+		return &RepoChangeMergeSettingAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			IsEnabled:      n.IsEnabled,
+			MergeType:      n.MergeType,
+			RepositoryName: n.RepositoryName,
+		}
+	case "repo.config.disable_anonymous_git_access":
+		return &RepoConfigDisableAnonymousGitAccessAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+		}
+	case "repo.config.disable_collaborators_only":
+		return &RepoConfigDisableCollaboratorsOnlyAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+		}
+	case "repo.config.disable_contributors_only":
+		return &RepoConfigDisableContributorsOnlyAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+		}
+	case "repo.config.disable_sockpuppet_disallowed":
+		return &RepoConfigDisableSockpuppetDisallowedAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+		}
+	case "repo.config.enable_anonymous_git_access":
+		return &RepoConfigEnableAnonymousGitAccessAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+		}
+	case "repo.config.enable_collaborators_only":
+		return &RepoConfigEnableCollaboratorsOnlyAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+		}
+	case "repo.config.enable_contributors_only":
+		return &RepoConfigEnableContributorsOnlyAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+		}
+	case "repo.config.enable_sockpuppet_disallowed":
+		return &RepoConfigEnableSockpuppetDisallowedAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+		}
+	case "repo.config.lock_anonymous_git_access":
+		return &RepoConfigLockAnonymousGitAccessAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+		}
+	case "repo.config.unlock_anonymous_git_access":
+		return &RepoConfigUnlockAnonymousGitAccessAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+		}
+	case "repo.create":
+		return &RepoCreateAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			ForkParentName: n.ForkParentName,
+			ForkSourceName: n.ForkSourceName,
+			RepositoryName: n.RepositoryName,
+			Visibility:     n.Visibility,
+		}
+	case "repo.destroy":
+		return &RepoDestroyAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+			Visibility:     n.Visibility,
+		}
+	case "repo.remove_member":
+		return &RepoRemoveMemberAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+			Visibility:     n.Visibility,
+		}
+	case "repo.remove_topic":
+		return &RepoRemoveTopicAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+			TopicName:      n.TopicName,
+		}
+	case "repository_visibility_change.disable":
+		return &RepositoryVisibilityChangeDisableAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			EnterpriseSlug: n.EnterpriseSlug,
+		}
+	case "repository_visibility_change.enable":
+		return &RepositoryVisibilityChangeEnableAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			EnterpriseSlug: n.EnterpriseSlug,
+		}
+	case "team.add_member":
+		return &TeamAddMemberAuditEntry{
+			AuditEntry: auditEntryOf(n),
+			TeamName:   n.TeamName,
+		}
+	case "team.add_repository":
+		return &TeamAddRepositoryAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+			TeamName:       n.TeamName,
+		}
+	case "team.change_parent_team":
+		return &TeamChangeParentTeamAuditEntry{
+			AuditEntry:        auditEntryOf(n),
+			ParentTeamName:    n.ParentTeamName,
+			ParentTeamNameWas: n.ParentTeamNameWas,
+			TeamName:          n.TeamName,
+		}
+	case "team.remove_member":
+		return &TeamRemoveMemberAuditEntry{
+			AuditEntry: auditEntryOf(n),
+			TeamName:   n.TeamName,
+		}
+	case "team.remove_repository":
+		return &TeamRemoveRepositoryAuditEntry{
+			AuditEntry:     auditEntryOf(n),
+			RepositoryName: n.RepositoryName,
+			TeamName:       n.TeamName,
+		}
+	}
+
+	return &unknownEvent{n}
+}
+
+// auditEntryOf returns the fields of the passed node that every type of audit entry has.
+func auditEntryOf(n Node) AuditEntry {
+	return AuditEntry{
+		ID:               n.ID,
+		Action:           n.Action,
+		Actor:            n.Actor,
+		CreatedAt:        n.CreatedAt,
+		OrganizationName: n.OrganizationName,
+		User:             n.User,
+		Raw:              n.Raw,
+	}
+}
+
+func (e AuditEntry) node() Node {
+	return Node{
+		ID:               e.ID,
+		Action:           e.Action,
+		Actor:            e.Actor,
+		CreatedAt:        e.CreatedAt,
+		OrganizationName: e.OrganizationName,
+		User:             e.User,
+		Raw:              e.Raw,
+	}
+}
+
+func (e *MembersCanDeleteReposClearAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.EnterpriseSlug = e.EnterpriseSlug
+	return n
+}
+
+func (e *MembersCanDeleteReposClearAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *MembersCanDeleteReposClearAuditEntry) Describe() string {
+	return fmt.Sprintf("The setting allowing members to delete repos in organisation *%s* was cleared by %s.", e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *MembersCanDeleteReposClearAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *MembersCanDeleteReposClearAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *MembersCanDeleteReposDisableAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.EnterpriseSlug = e.EnterpriseSlug
+	return n
+}
+
+func (e *MembersCanDeleteReposDisableAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *MembersCanDeleteReposDisableAuditEntry) Describe() string {
+	return fmt.Sprintf("Members were prevented from deleting repos in organisation *%s* by %s.", e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *MembersCanDeleteReposDisableAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *MembersCanDeleteReposDisableAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *MembersCanDeleteReposEnableAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.EnterpriseSlug = e.EnterpriseSlug
+	return n
+}
+
+func (e *MembersCanDeleteReposEnableAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *MembersCanDeleteReposEnableAuditEntry) Describe() string {
+	return fmt.Sprintf("Members were allowed to delete repos in organisation *%s* by %s.", e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *MembersCanDeleteReposEnableAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *MembersCanDeleteReposEnableAuditEntry) Severity() Severity {
+	return Warning
+}
+
+func (e *OauthApplicationCreateAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.OauthApplicationName = e.OauthApplicationName
+	return n
+}
+
+func (e *OauthApplicationCreateAuditEntry) Target() string {
+	return fmt.Sprintf("OAuth app *%s*", e.OauthApplicationName)
+}
+
+func (e *OauthApplicationCreateAuditEntry) Describe() string {
+	return fmt.Sprintf("New OAuth app *%s* was created within organisation *%s* by %s.", e.OauthApplicationName, e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *OauthApplicationCreateAuditEntry) Category() Category {
+	return CategoryOAuth
+}
+
+func (e *OauthApplicationCreateAuditEntry) Severity() Severity {
+	return Warning
+}
+
+func (e *OrgAddBillingManagerAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.Email = e.Email
+	return n
+}
+
+func (e *OrgAddBillingManagerAuditEntry) Target() string {
+	return userOrEmail(e.Node(), false)
+}
+
+func (e *OrgAddBillingManagerAuditEntry) Describe() string {
+	return fmt.Sprintf("%s added %s as billing manager for organisation *%s*.", e.Actor.Describe(true), userOrEmail(e.Node(), false), e.OrganizationName)
+}
+
+func (e *OrgAddBillingManagerAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgAddBillingManagerAuditEntry) Severity() Severity {
+	return Warning
+}
+
+func (e *OrgAddMemberAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.Permission = e.Permission
+	return n
+}
+
+func (e *OrgAddMemberAuditEntry) Target() string {
+	return e.User.Describe(false)
+}
+
+func (e *OrgAddMemberAuditEntry) Describe() string {
+	return fmt.Sprintf("%s accepted invitation to join organisation *%s*.", e.User.Describe(true), e.OrganizationName)
+}
+
+func (e *OrgAddMemberAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgAddMemberAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgBlockUserAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.BlockedUser = e.BlockedUser
+	return n
+}
+
+func (e *OrgBlockUserAuditEntry) Target() string {
+	return e.BlockedUser.Describe(false)
+}
+
+func (e *OrgBlockUserAuditEntry) Describe() string {
+	return fmt.Sprintf("%s was blocked by %s in organisation *%s*.", e.BlockedUser.Describe(true), e.Actor.Describe(false), e.OrganizationName)
+}
+
+func (e *OrgBlockUserAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgBlockUserAuditEntry) Severity() Severity {
+	return Warning
+}
+
+func (e *OrgConfigDisableCollaboratorsOnlyAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	return n
+}
+
+func (e *OrgConfigDisableCollaboratorsOnlyAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *OrgConfigDisableCollaboratorsOnlyAuditEntry) Describe() string {
+	return fmt.Sprintf("Interactions in organisation *%s* were no longer limited to collaborators by %s.", e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *OrgConfigDisableCollaboratorsOnlyAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgConfigDisableCollaboratorsOnlyAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgConfigEnableCollaboratorsOnlyAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	return n
+}
+
+func (e *OrgConfigEnableCollaboratorsOnlyAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *OrgConfigEnableCollaboratorsOnlyAuditEntry) Describe() string {
+	return fmt.Sprintf("Interactions in organisation *%s* were limited to collaborators by %s.", e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *OrgConfigEnableCollaboratorsOnlyAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgConfigEnableCollaboratorsOnlyAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgCreateAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.BillingPlan = e.BillingPlan
+	return n
+}
+
+func (e *OrgCreateAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *OrgCreateAuditEntry) Describe() string {
+	return fmt.Sprintf("Organisation *%s* was created by %s.", e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *OrgCreateAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgCreateAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgDisableOauthAppRestrictionsAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	return n
+}
+
+func (e *OrgDisableOauthAppRestrictionsAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *OrgDisableOauthAppRestrictionsAuditEntry) Describe() string {
+	return fmt.Sprintf("OAuth app restrictions were disabled for organisation *%s* by %s.", e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *OrgDisableOauthAppRestrictionsAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgDisableOauthAppRestrictionsAuditEntry) Severity() Severity {
+	return Critical
+}
+
+func (e *OrgDisableSamlAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	return n
+}
+
+func (e *OrgDisableSamlAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *OrgDisableSamlAuditEntry) Describe() string {
+	return fmt.Sprintf("SAML was disabled for organisation *%s* by %s.", e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *OrgDisableSamlAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgDisableSamlAuditEntry) Severity() Severity {
+	return Critical
+}
+
+func (e *OrgDisableTwoFactorRequirementAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	return n
+}
+
+func (e *OrgDisableTwoFactorRequirementAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *OrgDisableTwoFactorRequirementAuditEntry) Describe() string {
+	return fmt.Sprintf("Two-factor authentication was disabled for organisation *%s* by %s.", e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *OrgDisableTwoFactorRequirementAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgDisableTwoFactorRequirementAuditEntry) Severity() Severity {
+	return Critical
+}
+
+func (e *OrgEnableOauthAppRestrictionsAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	return n
+}
+
+func (e *OrgEnableOauthAppRestrictionsAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *OrgEnableOauthAppRestrictionsAuditEntry) Describe() string {
+	return fmt.Sprintf("OAuth app restrictions were enabled for organisation *%s* by %s.", e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *OrgEnableOauthAppRestrictionsAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgEnableOauthAppRestrictionsAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgEnableSamlAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	return n
+}
+
+func (e *OrgEnableSamlAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *OrgEnableSamlAuditEntry) Describe() string {
+	return fmt.Sprintf("SAML was enabled for organisation *%s* by %s.", e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *OrgEnableSamlAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgEnableSamlAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgEnableTwoFactorRequirementAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	return n
+}
+
+func (e *OrgEnableTwoFactorRequirementAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *OrgEnableTwoFactorRequirementAuditEntry) Describe() string {
+	return fmt.Sprintf("Two-factor authentication was enabled for organisation *%s* by %s.", e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *OrgEnableTwoFactorRequirementAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgEnableTwoFactorRequirementAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgInviteMemberAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.Email = e.Email
+	return n
+}
+
+func (e *OrgInviteMemberAuditEntry) Target() string {
+	return userOrEmail(e.Node(), false)
+}
+
+func (e *OrgInviteMemberAuditEntry) Describe() string {
+	return fmt.Sprintf("%s invited %s to join organisation *%s*.", e.Actor.Describe(true), userOrEmail(e.Node(), false), e.OrganizationName)
+}
+
+func (e *OrgInviteMemberAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgInviteMemberAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgInviteToBusinessAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.EnterpriseSlug = e.EnterpriseSlug
+	return n
+}
+
+func (e *OrgInviteToBusinessAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *OrgInviteToBusinessAuditEntry) Describe() string {
+	return fmt.Sprintf("%s invited organisation *%s* to join enterprise *%s*.", e.Actor.Describe(true), e.OrganizationName, e.EnterpriseSlug)
+}
+
+func (e *OrgInviteToBusinessAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgInviteToBusinessAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgOauthAppAccessApprovedAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.OauthApplicationName = e.OauthApplicationName
+	return n
+}
+
+func (e *OrgOauthAppAccessApprovedAuditEntry) Target() string {
+	return fmt.Sprintf("OAuth app *%s*", e.OauthApplicationName)
+}
+
+func (e *OrgOauthAppAccessApprovedAuditEntry) Describe() string {
+	return fmt.Sprintf("OAuth app *%s* within organisation *%s* had access approved by %s.", e.OauthApplicationName, e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *OrgOauthAppAccessApprovedAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgOauthAppAccessApprovedAuditEntry) Severity() Severity {
+	return Warning
+}
+
+func (e *OrgOauthAppAccessDeniedAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.OauthApplicationName = e.OauthApplicationName
+	return n
+}
+
+func (e *OrgOauthAppAccessDeniedAuditEntry) Target() string {
+	return fmt.Sprintf("OAuth app *%s*", e.OauthApplicationName)
+}
+
+func (e *OrgOauthAppAccessDeniedAuditEntry) Describe() string {
+	return fmt.Sprintf("OAuth app *%s* within organisation *%s* had access denied by %s.", e.OauthApplicationName, e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *OrgOauthAppAccessDeniedAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgOauthAppAccessDeniedAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgOauthAppAccessRequestedAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.OauthApplicationName = e.OauthApplicationName
+	return n
+}
+
+func (e *OrgOauthAppAccessRequestedAuditEntry) Target() string {
+	return fmt.Sprintf("OAuth app *%s*", e.OauthApplicationName)
+}
+
+func (e *OrgOauthAppAccessRequestedAuditEntry) Describe() string {
+	return fmt.Sprintf("Access to OAuth app *%s* within organisation *%s* was requested by %s.", e.OauthApplicationName, e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *OrgOauthAppAccessRequestedAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgOauthAppAccessRequestedAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgRemoveBillingManagerAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.Reason = e.Reason
+	return n
+}
+
+func (e *OrgRemoveBillingManagerAuditEntry) Target() string {
+	return e.User.Describe(false)
+}
+
+func (e *OrgRemoveBillingManagerAuditEntry) Describe() string {
+	return fmt.Sprintf("%s removed %s as billing manager from organisation *%s*.", e.Actor.Describe(true), e.User.Describe(false), e.OrganizationName)
+}
+
+func (e *OrgRemoveBillingManagerAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgRemoveBillingManagerAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgRemoveMemberAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.MembershipTypes = e.MembershipTypes
+	n.Reason = e.Reason
+	return n
+}
+
+func (e *OrgRemoveMemberAuditEntry) Target() string {
+	return e.User.Describe(false)
+}
+
+func (e *OrgRemoveMemberAuditEntry) Describe() string {
+	return fmt.Sprintf("%s removed %s from organisation *%s*.", e.Actor.Describe(true), e.User.Describe(false), e.OrganizationName)
+}
+
+func (e *OrgRemoveMemberAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgRemoveMemberAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgRemoveOutsideCollaboratorAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.MembershipTypes = e.MembershipTypes
+	n.Reason = e.Reason
+	return n
+}
+
+func (e *OrgRemoveOutsideCollaboratorAuditEntry) Target() string {
+	return e.User.Describe(false)
+}
+
+func (e *OrgRemoveOutsideCollaboratorAuditEntry) Describe() string {
+	return fmt.Sprintf("%s removed %s as an outside collaborator from organisation *%s*.", e.Actor.Describe(true), e.User.Describe(false), e.OrganizationName)
+}
+
+func (e *OrgRemoveOutsideCollaboratorAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgRemoveOutsideCollaboratorAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgRestoreMemberAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	return n
+}
+
+func (e *OrgRestoreMemberAuditEntry) Target() string {
+	return e.User.Describe(false)
+}
+
+func (e *OrgRestoreMemberAuditEntry) Describe() string {
+	return fmt.Sprintf("%s restored %s as a member of organisation *%s*.", e.Actor.Describe(true), e.User.Describe(false), e.OrganizationName)
+}
+
+func (e *OrgRestoreMemberAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgRestoreMemberAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgUnblockUserAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.BlockedUser = e.BlockedUser
+	return n
+}
+
+func (e *OrgUnblockUserAuditEntry) Target() string {
+	return e.BlockedUser.Describe(false)
+}
+
+func (e *OrgUnblockUserAuditEntry) Describe() string {
+	return fmt.Sprintf("%s was unblocked by %s in organisation *%s*.", e.BlockedUser.Describe(true), e.Actor.Describe(false), e.OrganizationName)
+}
+
+func (e *OrgUnblockUserAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgUnblockUserAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgUpdateDefaultRepositoryPermissionAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.Permission = e.Permission
+	n.PermissionWas = e.PermissionWas
+	return n
+}
+
+func (e *OrgUpdateDefaultRepositoryPermissionAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *OrgUpdateDefaultRepositoryPermissionAuditEntry) Describe() string {
+	return fmt.Sprintf("%s changed the default repo permission of organisation *%s* from *%s* to *%s*.", e.Actor.Describe(true), e.OrganizationName, strings.ToLower(e.PermissionWas), strings.ToLower(e.Permission))
+}
+
+func (e *OrgUpdateDefaultRepositoryPermissionAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgUpdateDefaultRepositoryPermissionAuditEntry) Severity() Severity {
+	return Warning
+}
+
+func (e *OrgUpdateMemberAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.Permission = e.Permission
+	n.PermissionWas = e.PermissionWas
+	return n
+}
+
+func (e *OrgUpdateMemberAuditEntry) Target() string {
+	return e.User.Describe(false)
+}
+
+func (e *OrgUpdateMemberAuditEntry) Describe() string {
+	return fmt.Sprintf("%s changed the role of %s from *%s* to *%s* in organisation *%s*.", e.Actor.Describe(true), e.User.Describe(false), strings.ToLower(e.PermissionWas), strings.ToLower(e.Permission), e.OrganizationName)
+}
+
+func (e *OrgUpdateMemberAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgUpdateMemberAuditEntry) Severity() Severity {
+	return Warning
+}
+
+func (e *OrgUpdateMemberRepositoryCreationPermissionAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.CanCreateRepositories = e.CanCreateRepositories
+	n.Visibility = e.Visibility
+	return n
+}
+
+func (e *OrgUpdateMemberRepositoryCreationPermissionAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *OrgUpdateMemberRepositoryCreationPermissionAuditEntry) Describe() string {
+	return fmt.Sprintf("%s changed the repos members of organisation *%s* can create to *%s*.", e.Actor.Describe(true), e.OrganizationName, repositoryCreation(e.Node(), false))
+}
+
+func (e *OrgUpdateMemberRepositoryCreationPermissionAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgUpdateMemberRepositoryCreationPermissionAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *OrgUpdateMemberRepositoryInvitationPermissionAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.CanInviteOutsideCollaboratorsToRepositories = e.CanInviteOutsideCollaboratorsToRepositories
+	return n
+}
+
+func (e *OrgUpdateMemberRepositoryInvitationPermissionAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *OrgUpdateMemberRepositoryInvitationPermissionAuditEntry) Describe() string {
+	return fmt.Sprintf("%s %s outside collaborators to repos in organisation *%s*.", e.Actor.Describe(true), invitationPermission(e.Node(), false), e.OrganizationName)
+}
+
+func (e *OrgUpdateMemberRepositoryInvitationPermissionAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *OrgUpdateMemberRepositoryInvitationPermissionAuditEntry) Severity() Severity {
+	return Warning
+}
+
+func (e *PrivateRepositoryForkingDisableAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.EnterpriseSlug = e.EnterpriseSlug
+	n.RepositoryName = e.RepositoryName
+	return n
+}
+
+func (e *PrivateRepositoryForkingDisableAuditEntry) Target() string {
+	return forkingTarget(e.Node(), false)
+}
+
+func (e *PrivateRepositoryForkingDisableAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *PrivateRepositoryForkingDisableAuditEntry) Describe() string {
+	return fmt.Sprintf("Forking of private repos was disabled for %s by %s.", forkingTarget(e.Node(), false), e.Actor.Describe(false))
+}
+
+func (e *PrivateRepositoryForkingDisableAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *PrivateRepositoryForkingDisableAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *PrivateRepositoryForkingEnableAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.EnterpriseSlug = e.EnterpriseSlug
+	n.RepositoryName = e.RepositoryName
+	return n
+}
+
+func (e *PrivateRepositoryForkingEnableAuditEntry) Target() string {
+	return forkingTarget(e.Node(), false)
+}
+
+func (e *PrivateRepositoryForkingEnableAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *PrivateRepositoryForkingEnableAuditEntry) Describe() string {
+	return fmt.Sprintf("Forking of private repos was enabled for %s by %s.", forkingTarget(e.Node(), false), e.Actor.Describe(false))
+}
+
+func (e *PrivateRepositoryForkingEnableAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *PrivateRepositoryForkingEnableAuditEntry) Severity() Severity {
+	return Warning
+}
+
+func (e *RepoAccessAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	n.Visibility = e.Visibility
+	return n
+}
+
+func (e *RepoAccessAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoAccessAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoAccessAuditEntry) Describe() string {
+	return fmt.Sprintf("%s changed the visibility of repo *%s* to *%s*.", e.Actor.Describe(true), e.RepositoryName, strings.ToLower(e.Visibility))
+}
+
+func (e *RepoAccessAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoAccessAuditEntry) Severity() Severity {
+	return Warning
+}
+
+func (e *RepoAddMemberAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	n.Visibility = e.Visibility
+	return n
+}
+
+func (e *RepoAddMemberAuditEntry) Target() string {
+	return e.User.Describe(false)
+}
+
+func (e *RepoAddMemberAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoAddMemberAuditEntry) Describe() string {
+	return fmt.Sprintf("%s invited %s to collaborate on repo *%s*.", e.Actor.Describe(true), e.User.Describe(false), e.RepositoryName)
+}
+
+func (e *RepoAddMemberAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoAddMemberAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepoAddTopicAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	n.TopicName = e.TopicName
+	return n
+}
+
+func (e *RepoAddTopicAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoAddTopicAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoAddTopicAuditEntry) Describe() string {
+	return fmt.Sprintf("%s added topic(s) *%s* to repo *%s*.", e.Actor.Describe(true), e.TopicName, e.RepositoryName)
+}
+
+func (e *RepoAddTopicAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoAddTopicAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepoArchivedAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	n.Visibility = e.Visibility
+	return n
+}
+
+func (e *RepoArchivedAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoArchivedAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoArchivedAuditEntry) Describe() string {
+	return fmt.Sprintf("%s archived repo *%s*.", e.Actor.Describe(true), e.RepositoryName)
+}
+
+func (e *RepoArchivedAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoArchivedAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepoChangeMergeSettingAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.IsEnabled = e.IsEnabled
+	n.MergeType = e.MergeType
+	n.RepositoryName = e.RepositoryName
+	return n
+}
+
+func (e *RepoChangeMergeSettingAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoChangeMergeSettingAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoChangeMergeSettingAuditEntry) Describe() string {
+	if len(e.MergeType) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%s changed the merge setting of repo *%s* to *%s*.", e.Actor.Describe(true), e.RepositoryName, strings.ToLower(e.MergeType))
+}
+
+func (e *RepoChangeMergeSettingAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoChangeMergeSettingAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepoConfigDisableAnonymousGitAccessAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	return n
+}
+
+func (e *RepoConfigDisableAnonymousGitAccessAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoConfigDisableAnonymousGitAccessAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoConfigDisableAnonymousGitAccessAuditEntry) Describe() string {
+	return fmt.Sprintf("%s disabled anonymous Git read access to repo *%s*.", e.Actor.Describe(true), e.RepositoryName)
+}
+
+func (e *RepoConfigDisableAnonymousGitAccessAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoConfigDisableAnonymousGitAccessAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepoConfigDisableCollaboratorsOnlyAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	return n
+}
+
+func (e *RepoConfigDisableCollaboratorsOnlyAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoConfigDisableCollaboratorsOnlyAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoConfigDisableCollaboratorsOnlyAuditEntry) Describe() string {
+	return fmt.Sprintf("%s stopped limiting interactions in repo *%s* to collaborators.", e.Actor.Describe(true), e.RepositoryName)
+}
+
+func (e *RepoConfigDisableCollaboratorsOnlyAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoConfigDisableCollaboratorsOnlyAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepoConfigDisableContributorsOnlyAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	return n
+}
+
+func (e *RepoConfigDisableContributorsOnlyAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoConfigDisableContributorsOnlyAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoConfigDisableContributorsOnlyAuditEntry) Describe() string {
+	return fmt.Sprintf("%s stopped limiting interactions in repo *%s* to prior contributors.", e.Actor.Describe(true), e.RepositoryName)
+}
+
+func (e *RepoConfigDisableContributorsOnlyAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoConfigDisableContributorsOnlyAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepoConfigDisableSockpuppetDisallowedAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	return n
+}
+
+func (e *RepoConfigDisableSockpuppetDisallowedAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoConfigDisableSockpuppetDisallowedAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoConfigDisableSockpuppetDisallowedAuditEntry) Describe() string {
+	return fmt.Sprintf("%s stopped limiting interactions in repo *%s* to existing users.", e.Actor.Describe(true), e.RepositoryName)
+}
+
+func (e *RepoConfigDisableSockpuppetDisallowedAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoConfigDisableSockpuppetDisallowedAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepoConfigEnableAnonymousGitAccessAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	return n
+}
+
+func (e *RepoConfigEnableAnonymousGitAccessAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoConfigEnableAnonymousGitAccessAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoConfigEnableAnonymousGitAccessAuditEntry) Describe() string {
+	return fmt.Sprintf("%s enabled anonymous Git read access to repo *%s*.", e.Actor.Describe(true), e.RepositoryName)
+}
+
+func (e *RepoConfigEnableAnonymousGitAccessAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoConfigEnableAnonymousGitAccessAuditEntry) Severity() Severity {
+	return Critical
+}
+
+func (e *RepoConfigEnableCollaboratorsOnlyAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	return n
+}
+
+func (e *RepoConfigEnableCollaboratorsOnlyAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoConfigEnableCollaboratorsOnlyAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoConfigEnableCollaboratorsOnlyAuditEntry) Describe() string {
+	return fmt.Sprintf("%s limited interactions in repo *%s* to collaborators.", e.Actor.Describe(true), e.RepositoryName)
+}
+
+func (e *RepoConfigEnableCollaboratorsOnlyAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoConfigEnableCollaboratorsOnlyAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepoConfigEnableContributorsOnlyAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	return n
+}
+
+func (e *RepoConfigEnableContributorsOnlyAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoConfigEnableContributorsOnlyAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoConfigEnableContributorsOnlyAuditEntry) Describe() string {
+	return fmt.Sprintf("%s limited interactions in repo *%s* to prior contributors.", e.Actor.Describe(true), e.RepositoryName)
+}
+
+func (e *RepoConfigEnableContributorsOnlyAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoConfigEnableContributorsOnlyAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepoConfigEnableSockpuppetDisallowedAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	return n
+}
+
+func (e *RepoConfigEnableSockpuppetDisallowedAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoConfigEnableSockpuppetDisallowedAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoConfigEnableSockpuppetDisallowedAuditEntry) Describe() string {
+	return fmt.Sprintf("%s limited interactions in repo *%s* to existing users.", e.Actor.Describe(true), e.RepositoryName)
+}
+
+func (e *RepoConfigEnableSockpuppetDisallowedAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoConfigEnableSockpuppetDisallowedAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepoConfigLockAnonymousGitAccessAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	return n
+}
+
+func (e *RepoConfigLockAnonymousGitAccessAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoConfigLockAnonymousGitAccessAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoConfigLockAnonymousGitAccessAuditEntry) Describe() string {
+	return fmt.Sprintf("%s locked the anonymous Git read access setting of repo *%s*.", e.Actor.Describe(true), e.RepositoryName)
+}
+
+func (e *RepoConfigLockAnonymousGitAccessAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoConfigLockAnonymousGitAccessAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepoConfigUnlockAnonymousGitAccessAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	return n
+}
+
+func (e *RepoConfigUnlockAnonymousGitAccessAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoConfigUnlockAnonymousGitAccessAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoConfigUnlockAnonymousGitAccessAuditEntry) Describe() string {
+	return fmt.Sprintf("%s unlocked the anonymous Git read access setting of repo *%s*.", e.Actor.Describe(true), e.RepositoryName)
+}
+
+func (e *RepoConfigUnlockAnonymousGitAccessAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoConfigUnlockAnonymousGitAccessAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepoCreateAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.ForkParentName = e.ForkParentName
+	n.ForkSourceName = e.ForkSourceName
+	n.RepositoryName = e.RepositoryName
+	n.Visibility = e.Visibility
+	return n
+}
+
+func (e *RepoCreateAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoCreateAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoCreateAuditEntry) Describe() string {
+	return fmt.Sprintf("%s created repo *%s* with visibility *%s*.", e.Actor.Describe(true), e.RepositoryName, strings.ToLower(e.Visibility))
+}
+
+func (e *RepoCreateAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoCreateAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepoDestroyAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	n.Visibility = e.Visibility
+	return n
+}
+
+func (e *RepoDestroyAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoDestroyAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoDestroyAuditEntry) Describe() string {
+	return fmt.Sprintf("%s deleted repo *%s*.", e.Actor.Describe(true), e.RepositoryName)
+}
+
+func (e *RepoDestroyAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoDestroyAuditEntry) Severity() Severity {
+	return Critical
+}
+
+func (e *RepoRemoveMemberAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	n.Visibility = e.Visibility
+	return n
+}
+
+func (e *RepoRemoveMemberAuditEntry) Target() string {
+	return e.User.Describe(false)
+}
+
+func (e *RepoRemoveMemberAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoRemoveMemberAuditEntry) Describe() string {
+	return fmt.Sprintf("%s removed %s as a collaborator from repo *%s*.", e.Actor.Describe(true), e.User.Describe(false), e.RepositoryName)
+}
+
+func (e *RepoRemoveMemberAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoRemoveMemberAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepoRemoveTopicAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	n.TopicName = e.TopicName
+	return n
+}
+
+func (e *RepoRemoveTopicAuditEntry) Target() string {
+	return fmt.Sprintf("repo *%s*", e.RepositoryName)
+}
+
+func (e *RepoRemoveTopicAuditEntry) Scope() string {
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *RepoRemoveTopicAuditEntry) Describe() string {
+	return fmt.Sprintf("%s removed topic(s) *%s* from repo *%s*.", e.Actor.Describe(true), e.TopicName, e.RepositoryName)
+}
+
+func (e *RepoRemoveTopicAuditEntry) Category() Category {
+	return CategoryRepo
+}
+
+func (e *RepoRemoveTopicAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepositoryVisibilityChangeDisableAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.EnterpriseSlug = e.EnterpriseSlug
+	return n
+}
+
+func (e *RepositoryVisibilityChangeDisableAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *RepositoryVisibilityChangeDisableAuditEntry) Describe() string {
+	return fmt.Sprintf("Members were prevented from changing repo visibility in organisation *%s* by %s.", e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *RepositoryVisibilityChangeDisableAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *RepositoryVisibilityChangeDisableAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *RepositoryVisibilityChangeEnableAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.EnterpriseSlug = e.EnterpriseSlug
+	return n
+}
+
+func (e *RepositoryVisibilityChangeEnableAuditEntry) Target() string {
+	return fmt.Sprintf("organisation *%s*", e.OrganizationName)
+}
+
+func (e *RepositoryVisibilityChangeEnableAuditEntry) Describe() string {
+	return fmt.Sprintf("Members were allowed to change repo visibility in organisation *%s* by %s.", e.OrganizationName, e.Actor.Describe(false))
+}
+
+func (e *RepositoryVisibilityChangeEnableAuditEntry) Category() Category {
+	return CategoryOrg
+}
+
+func (e *RepositoryVisibilityChangeEnableAuditEntry) Severity() Severity {
+	return Warning
+}
+
+func (e *TeamAddMemberAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.TeamName = e.TeamName
+	return n
+}
+
+func (e *TeamAddMemberAuditEntry) Target() string {
+	return e.User.Describe(false)
+}
+
+func (e *TeamAddMemberAuditEntry) Scope() string {
+	if len(e.TeamName) > 0 {
+		return fmt.Sprintf("team *%s*", e.TeamName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *TeamAddMemberAuditEntry) Describe() string {
+	return fmt.Sprintf("%s added %s to team *%s*.", e.Actor.Describe(true), e.User.Describe(false), e.TeamName)
+}
+
+func (e *TeamAddMemberAuditEntry) Category() Category {
+	return CategoryTeam
+}
+
+func (e *TeamAddMemberAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *TeamAddRepositoryAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	n.TeamName = e.TeamName
+	return n
+}
+
+func (e *TeamAddRepositoryAuditEntry) Target() string {
+	return fmt.Sprintf("team *%s*", e.TeamName)
+}
+
+func (e *TeamAddRepositoryAuditEntry) Scope() string {
+	if len(e.TeamName) > 0 {
+		return fmt.Sprintf("team *%s*", e.TeamName)
+	}
+
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *TeamAddRepositoryAuditEntry) Describe() string {
+	return fmt.Sprintf("%s gave team *%s* control of repository *%s*.", e.Actor.Describe(true), e.TeamName, e.RepositoryName)
+}
+
+func (e *TeamAddRepositoryAuditEntry) Category() Category {
+	return CategoryTeam
+}
+
+func (e *TeamAddRepositoryAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *TeamChangeParentTeamAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.ParentTeamName = e.ParentTeamName
+	n.ParentTeamNameWas = e.ParentTeamNameWas
+	n.TeamName = e.TeamName
+	return n
+}
+
+func (e *TeamChangeParentTeamAuditEntry) Target() string {
+	return fmt.Sprintf("team *%s*", e.TeamName)
+}
+
+func (e *TeamChangeParentTeamAuditEntry) Scope() string {
+	if len(e.TeamName) > 0 {
+		return fmt.Sprintf("team *%s*", e.TeamName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *TeamChangeParentTeamAuditEntry) Describe() string {
+	return fmt.Sprintf("%s changed parent team of team *%s* to *%s*.", e.Actor.Describe(true), e.TeamName, e.ParentTeamName)
+}
+
+func (e *TeamChangeParentTeamAuditEntry) Category() Category {
+	return CategoryTeam
+}
+
+func (e *TeamChangeParentTeamAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *TeamRemoveMemberAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.TeamName = e.TeamName
+	return n
+}
+
+func (e *TeamRemoveMemberAuditEntry) Target() string {
+	return e.User.Describe(false)
+}
+
+func (e *TeamRemoveMemberAuditEntry) Scope() string {
+	if len(e.TeamName) > 0 {
+		return fmt.Sprintf("team *%s*", e.TeamName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *TeamRemoveMemberAuditEntry) Describe() string {
+	return fmt.Sprintf("%s removed %s from team *%s*.", e.Actor.Describe(true), e.User.Describe(false), e.TeamName)
+}
+
+func (e *TeamRemoveMemberAuditEntry) Category() Category {
+	return CategoryTeam
+}
+
+func (e *TeamRemoveMemberAuditEntry) Severity() Severity {
+	return Info
+}
+
+func (e *TeamRemoveRepositoryAuditEntry) Node() Node {
+	n := e.AuditEntry.node()
+	n.RepositoryName = e.RepositoryName
+	n.TeamName = e.TeamName
+	return n
+}
+
+func (e *TeamRemoveRepositoryAuditEntry) Target() string {
+	return fmt.Sprintf("team *%s*", e.TeamName)
+}
+
+func (e *TeamRemoveRepositoryAuditEntry) Scope() string {
+	if len(e.TeamName) > 0 {
+		return fmt.Sprintf("team *%s*", e.TeamName)
+	}
+
+	if len(e.RepositoryName) > 0 {
+		return fmt.Sprintf("repo *%s*", e.RepositoryName)
+	}
+
+	return e.AuditEntry.Scope()
+}
+
+func (e *TeamRemoveRepositoryAuditEntry) Describe() string {
+	return fmt.Sprintf("%s removed control from team *%s* of repository *%s*.", e.Actor.Describe(true), e.TeamName, e.RepositoryName)
+}
+
+func (e *TeamRemoveRepositoryAuditEntry) Category() Category {
+	return CategoryTeam
+}
+
+func (e *TeamRemoveRepositoryAuditEntry) Severity() Severity {
+	return Info
+}
+
+// eventInfo maps each GitHub action to its description string with format specifiers, category and severity.
+var eventInfo = map[string]EventInfo{
+	"members_can_delete_repos.clear": {
+		Message:  "The setting allowing members to delete repos in organisation *%s* was cleared by %s.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"members_can_delete_repos.disable": {
+		Message:  "Members were prevented from deleting repos in organisation *%s* by %s.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"members_can_delete_repos.enable": {
+		Message:  "Members were allowed to delete repos in organisation *%s* by %s.",
+		Category: CategoryOrg,
+		Severity: Warning,
+	},
+	"oauth_application.create": {
+		Message:  "New OAuth app *%s* was created within organisation *%s* by %s.",
+		Category: CategoryOAuth,
+		Severity: Warning,
+	},
+	"org.add_billing_manager": {
+		Message:  "%s added %s as billing manager for organisation *%s*.",
+		Category: CategoryOrg,
+		Severity: Warning,
+	},
+	"org.add_member": {
+		Message:  "%s accepted invitation to join organisation *%s*.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.block_user": {
+		Message:  "%s was blocked by %s in organisation *%s*.",
+		Category: CategoryOrg,
+		Severity: Warning,
+	},
+	"org.config.disable_collaborators_only": {
+		Message:  "Interactions in organisation *%s* were no longer limited to collaborators by %s.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.config.enable_collaborators_only": {
+		Message:  "Interactions in organisation *%s* were limited to collaborators by %s.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.create": {
+		Message:  "Organisation *%s* was created by %s.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.disable_oauth_app_restrictions": {
+		Message:  "OAuth app restrictions were disabled for organisation *%s* by %s.",
+		Category: CategoryOrg,
+		Severity: Critical,
+	},
+	"org.disable_saml": {
+		Message:  "SAML was disabled for organisation *%s* by %s.",
+		Category: CategoryOrg,
+		Severity: Critical,
+	},
+	"org.disable_two_factor_requirement": {
+		Message:  "Two-factor authentication was disabled for organisation *%s* by %s.",
+		Category: CategoryOrg,
+		Severity: Critical,
+	},
+	"org.enable_oauth_app_restrictions": {
+		Message:  "OAuth app restrictions were enabled for organisation *%s* by %s.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.enable_saml": {
+		Message:  "SAML was enabled for organisation *%s* by %s.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.enable_two_factor_requirement": {
+		Message:  "Two-factor authentication was enabled for organisation *%s* by %s.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.invite_member": {
+		Message:  "%s invited %s to join organisation *%s*.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.invite_to_business": {
+		Message:  "%s invited organisation *%s* to join enterprise *%s*.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.oauth_app_access_approved": {
+		Message:  "OAuth app *%s* within organisation *%s* had access approved by %s.",
+		Category: CategoryOrg,
+		Severity: Warning,
+	},
+	"org.oauth_app_access_denied": {
+		Message:  "OAuth app *%s* within organisation *%s* had access denied by %s.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.oauth_app_access_requested": {
+		Message:  "Access to OAuth app *%s* within organisation *%s* was requested by %s.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.remove_billing_manager": {
+		Message:  "%s removed %s as billing manager from organisation *%s*.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.remove_member": {
+		Message:  "%s removed %s from organisation *%s*.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.remove_outside_collaborator": {
+		Message:  "%s removed %s as an outside collaborator from organisation *%s*.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.restore_member": {
+		Message:  "%s restored %s as a member of organisation *%s*.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.unblock_user": {
+		Message:  "%s was unblocked by %s in organisation *%s*.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.update_default_repository_permission": {
+		Message:  "%s changed the default repo permission of organisation *%s* from *%s* to *%s*.",
+		Category: CategoryOrg,
+		Severity: Warning,
+	},
+	"org.update_member": {
+		Message:  "%s changed the role of %s from *%s* to *%s* in organisation *%s*.",
+		Category: CategoryOrg,
+		Severity: Warning,
+	},
+	"org.update_member_repository_creation_permission": {
+		Message:  "%s changed the repos members of organisation *%s* can create to *%s*.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"org.update_member_repository_invitation_permission": {
+		Message:  "%s %s outside collaborators to repos in organisation *%s*.",
+		Category: CategoryOrg,
+		Severity: Warning,
+	},
+	"private_repository_forking.disable": {
+		Message:  "Forking of private repos was disabled for %s by %s.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"private_repository_forking.enable": {
+		Message:  "Forking of private repos was enabled for %s by %s.",
+		Category: CategoryOrg,
+		Severity: Warning,
+	},
+	"repo.access": {
+		Message:  "%s changed the visibility of repo *%s* to *%s*.",
+		Category: CategoryRepo,
+		Severity: Warning,
+	},
+	"repo.add_member": {
+		Message:  "%s invited %s to collaborate on repo *%s*.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repo.add_topic": {
+		Message:  "%s added topic(s) *%s* to repo *%s*.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repo.archived": {
+		Message:  "%s archived repo *%s*.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repo.change_merge_setting": {
+		Message:  "%s changed the merge setting of repo *%s* to *%s*.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repo.config.disable_anonymous_git_access": {
+		Message:  "%s disabled anonymous Git read access to repo *%s*.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repo.config.disable_collaborators_only": {
+		Message:  "%s stopped limiting interactions in repo *%s* to collaborators.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repo.config.disable_contributors_only": {
+		Message:  "%s stopped limiting interactions in repo *%s* to prior contributors.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repo.config.disable_sockpuppet_disallowed": {
+		Message:  "%s stopped limiting interactions in repo *%s* to existing users.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repo.config.enable_anonymous_git_access": {
+		Message:  "%s enabled anonymous Git read access to repo *%s*.",
+		Category: CategoryRepo,
+		Severity: Critical,
+	},
+	"repo.config.enable_collaborators_only": {
+		Message:  "%s limited interactions in repo *%s* to collaborators.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repo.config.enable_contributors_only": {
+		Message:  "%s limited interactions in repo *%s* to prior contributors.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repo.config.enable_sockpuppet_disallowed": {
+		Message:  "%s limited interactions in repo *%s* to existing users.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repo.config.lock_anonymous_git_access": {
+		Message:  "%s locked the anonymous Git read access setting of repo *%s*.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repo.config.unlock_anonymous_git_access": {
+		Message:  "%s unlocked the anonymous Git read access setting of repo *%s*.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repo.create": {
+		Message:  "%s created repo *%s* with visibility *%s*.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repo.destroy": {
+		Message:  "%s deleted repo *%s*.",
+		Category: CategoryRepo,
+		Severity: Critical,
+	},
+	"repo.remove_member": {
+		Message:  "%s removed %s as a collaborator from repo *%s*.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repo.remove_topic": {
+		Message:  "%s removed topic(s) *%s* from repo *%s*.",
+		Category: CategoryRepo,
+		Severity: Info,
+	},
+	"repository_visibility_change.disable": {
+		Message:  "Members were prevented from changing repo visibility in organisation *%s* by %s.",
+		Category: CategoryOrg,
+		Severity: Info,
+	},
+	"repository_visibility_change.enable": {
+		Message:  "Members were allowed to change repo visibility in organisation *%s* by %s.",
+		Category: CategoryOrg,
+		Severity: Warning,
+	},
+	"team.add_member": {
+		Message:  "%s added %s to team *%s*.",
+		Category: CategoryTeam,
+		Severity: Info,
+	},
+	"team.add_repository": {
+		Message:  "%s gave team *%s* control of repository *%s*.",
+		Category: CategoryTeam,
+		Severity: Info,
+	},
+	"team.change_parent_team": {
+		Message:  "%s changed parent team of team *%s* to *%s*.",
+		Category: CategoryTeam,
+		Severity: Info,
+	},
+	"team.remove_member": {
+		Message:  "%s removed %s from team *%s*.",
+		Category: CategoryTeam,
+		Severity: Info,
+	},
+	"team.remove_repository": {
+		Message:  "%s removed control from team *%s* of repository *%s*.",
+		Category: CategoryTeam,
+		Severity: Info,
+	},
+}
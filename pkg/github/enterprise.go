@@ -0,0 +1,77 @@
+package github
+
+import (
+	"context"
+
+	"github.com/ONSdigital/graphql"
+	"github.com/pkg/errors"
+)
+
+// EnterpriseOrganizations returns the logins of every organisation in the GitHub enterprise with the passed slug.
+func (c Client) EnterpriseOrganizations(ctx context.Context, enterprise string) ([]string, error) {
+	var endCursor *string // Using a pointer type allows this to be nil (an empty string isn't a valid cursor).
+
+	req := graphql.NewRequest(`
+		query GitHubEnterpriseOrganizations($slug: String!, $after: String) {
+			rateLimit {
+				cost
+				remaining
+				resetAt
+			}
+			enterprise(slug: $slug) {
+				organizations(first: 100, after: $after) {
+					pageInfo {
+						endCursor
+						hasNextPage
+					}
+					nodes {
+						login
+					}
+				}
+			}
+		}
+	`)
+
+	req.Var("slug", enterprise)
+
+	var organisations []string
+	hasNextPage := true
+
+	for page := 1; hasNextPage; page++ {
+		res := &struct {
+			RateLimit  RateLimit
+			Enterprise *struct {
+				Organizations struct {
+					PageInfo PageInfo
+					Nodes    []struct {
+						Login string
+					}
+				}
+			}
+		}{}
+		req.Var("after", endCursor)
+
+		if err := c.Run(ctx, req, &res); err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch page %d of organisations for enterprise", page)
+		}
+
+		if res.Enterprise == nil {
+			return nil, errors.Errorf("enterprise '%s' not found", enterprise)
+		}
+
+		for _, node := range res.Enterprise.Organizations.Nodes {
+			organisations = append(organisations, node.Login)
+		}
+
+		endCursor = &res.Enterprise.Organizations.PageInfo.EndCursor
+		hasNextPage = res.Enterprise.Organizations.PageInfo.HasNextPage
+
+		if hasNextPage {
+			if err := waitForRateLimit(ctx, res.RateLimit); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return organisations, nil
+}
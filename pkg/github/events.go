@@ -1,51 +1,55 @@
 package github
 
+import "strings"
+
+//go:generate go run ../../cmd/genauditlog
+
+type (
+
+	// Category is the area of GitHub a GitHub action relates to.
+	Category string
+
+	// Severity is how urgently a GitHub action needs attention.
+	Severity string
+
+	// EventInfo describes a GitHub action.
+	EventInfo struct {
+		Message  string
+		Category Category
+		Severity Severity
+	}
+)
+
+// Categories of GitHub actions, used to route alerts.
+const (
+	CategoryOAuth Category = "oauth"
+	CategoryOrg   Category = "org"
+	CategoryRepo  Category = "repo"
+	CategoryTeam  Category = "team"
+)
+
+// Severities of GitHub actions, in increasing order of urgency.
+const (
+	Info     Severity = "info"
+	Warning  Severity = "warning"
+	Critical Severity = "critical"
+)
+
 // MessageForEvent returns a description string with format specifiers for the passed GitHub action.
-// To avoid confusion, the user who initiated an action (the actor) should always appear before the
-// user affected by the action (the user).
 func MessageForEvent(action string) string {
-	eventMap := map[string]string{
-
-		// OAuth events.
-		"oauth_application.create": "New OAuth app *%s* was created within organisation *%s* by %s.",
-
-		// Organisation events.
-		"org.add_billing_manager":            "%s added %s as billing manager for organisation *%s*.",
-		"org.add_member":                     "%s accepted invitation to join organisation *%s*.",
-		"org.block_user":                     "%s was blocked by %s in organisation *%s*.",
-		"org.create":                         "Organisation *%s* was created by %s.",
-		"org.disable_saml":                   "SAML was disabled for organisation *%s* by %s.",
-		"org.disable_two_factor_requirement": "Two-factor authentication was disabled for organisation *%s* by %s.",
-		"org.enable_oauth_app_restrictions":  "OAuth app restrictions were enabled for organisation *%s* by %s.",
-		"org.enable_saml":                    "SAML was enabled for organisation *%s* by %s.",
-		"org.enable_two_factor_requirement":  "Two-factor authentication was enabled for organisation *%s* by %s.",
-		"org.invite_member":                  "%s invited %s to join organisation *%s*.",
-		"org.oauth_app_access_approved":      "OAuth app *%s* within organisation *%s* had access approved by %s.",
-		"org.oauth_app_access_denied":        "OAuth app *%s* within organisation *%s* had access denied by %s.",
-		"org.oauth_app_access_requested":     "Access to OAuth app *%s* within organisation *%s* was requested by %s.",
-		"org.remove_billing_manager":         "%s removed %s as billing manager from organisation *%s*.",
-		"org.remove_member":                  "%s removed %s from organisation *%s*.",
-		"org.remove_outside_collaborator":    "%s removed %s as an outside collaborator from organisation *%s*.",
-		"org.restore_member":                 "%s restored %s as a member of organisation *%s*.",
-		"org.update_member":                  "%s changed the role of %s from *%s* to *%s* in organisation *%s*.",
-
-		// Repo events.
-		"repo.access":               "%s changed the visibility of repo *%s* to *%s*.",
-		"repo.add_member":           "%s invited %s to collaborate on repo *%s*.",
-		"repo.add_topic":            "%s added topic(s) *%s* to repo *%s*.",
-		"repo.archived":             "%s archived repo *%s*.",
-		"repo.change_merge_setting": "%s changed the merge setting of repo *%s* to *%s*.",
-		"repo.create":               "%s created repo *%s* with visibility *%s*.",
-		"repo.destroy":              "%s deleted repo *%s*.",
-		"repo.remove_member":        "%s removed %s as a collaborator from repo *%s*.",
-
-		// Team events.
-		"team.add_member":         "%s added %s to team *%s*.",
-		"team.add_repository":     "%s gave team *%s* control of repository *%s*.",
-		"team.change_parent_team": "%s changed parent team of team *%s* to *%s*.",
-		"team.remove_member":      "%s removed %s from team *%s*.",
-		"team.remove_repository":  "%s removed control from team *%s* of repository *%s*.",
+	return eventInfo[action].Message
+}
+
+// InfoForEvent returns the description, category and severity of the passed GitHub action, and whether the action is known.
+// Unknown actions are given the category taken from their prefix (e.g. "repo" for "repo.rename") and Info severity.
+func InfoForEvent(action string) (EventInfo, bool) {
+	info, ok := eventInfo[action]
+	if !ok {
+		info = EventInfo{
+			Category: Category(strings.SplitN(action, ".", 2)[0]),
+			Severity: Info,
+		}
 	}
 
-	return eventMap[action]
+	return info, ok
 }
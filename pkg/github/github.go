@@ -2,31 +2,100 @@ package github
 
 import (
 	"context"
+	"log"
+	"net/http"
+	"time"
 
 	"github.com/ONSdigital/graphql"
 )
 
 type (
 
-	// Client wraps a GraphQL client for communicating with the GitHub API.
+	// Client wraps a GraphQL client and an HTTP client for communicating with the GitHub GraphQL and REST APIs.
 	Client struct {
-		token  string
-		client *graphql.Client
+		tokens     TokenSource
+		client     *graphql.Client
+		httpClient *http.Client
+		restURL    string
 	}
+
+	// RateLimit represents the GraphQL API rate limit status returned alongside a query.
+	RateLimit struct {
+		Cost      int
+		Remaining int
+		ResetAt   string
+	}
+)
+
+const (
+	endpoint = "https://api.github.com/graphql"
+
+	// rateLimitReserve is the number of query costs to keep in reserve before pausing until the rate limit resets.
+	rateLimitReserve = 2
 )
 
-const endpoint = "https://api.github.com/graphql"
+// NewClient instantiates a new GraphQL client authenticated using the passed personal access token and configured using the passed
+// options. Requests failing with transient errors or because of rate limiting are retried.
+func NewClient(token string, opts ...Option) *Client {
+	return newClient(staticToken(token), newOptions(opts))
+}
+
+// NewClientWithTokenSource instantiates a new GraphQL client authenticated using tokens from the passed source and configured using
+// the passed options.
+func NewClientWithTokenSource(tokens TokenSource, opts ...Option) *Client {
+	return newClient(tokens, newOptions(opts))
+}
+
+func newClient(tokens TokenSource, o options) *Client {
+	httpClient := o.httpClient()
+	graphQLURL, restURL := o.endpoints()
 
-// NewClient instantiates a new GraphQL client.
-func NewClient(token string) *Client {
 	return &Client{
-		token:  token,
-		client: graphql.NewClient(endpoint),
+		tokens:     tokens,
+		client:     graphql.NewClient(graphQLURL, graphql.WithHTTPClient(httpClient)),
+		httpClient: httpClient,
+		restURL:    restURL,
+	}
+}
+
+// Run wraps the underlying graphql.Run function, automatically adding an authentication header.
+func (c Client) Run(ctx context.Context, request *graphql.Request, response interface{}) error {
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return err
 	}
+
+	request.Header.Set("Authorization", "Bearer "+token)
+	return c.client.Run(ctx, request, response)
 }
 
-// Run wraps the underlying graphql.Run function, authomatically adding an authentication header and background context.
-func (c Client) Run(request *graphql.Request, response interface{}) error {
-	request.Header.Set("Authorization", "Bearer "+c.token)
-	return c.client.Run(context.Background(), request, response)
+// waitForRateLimit pauses until the rate limit resets if the passed rate limit status shows the remaining budget can't cover
+// rateLimitReserve more queries of the same cost, so the budget is never exhausted mid-fetch. An error is returned if the passed
+// context is cancelled while waiting.
+func waitForRateLimit(ctx context.Context, rateLimit RateLimit) error {
+	if rateLimit.Remaining >= rateLimit.Cost*rateLimitReserve {
+		return nil
+	}
+
+	resetAt, err := time.Parse(time.RFC3339, rateLimit.ResetAt)
+	if err != nil {
+		return nil
+	}
+
+	delay := resetAt.Sub(now())
+	if delay <= 0 {
+		return nil
+	}
+
+	log.Printf("GitHub API rate limit nearly exhausted (%d remaining), pausing until %s", rateLimit.Remaining, rateLimit.ResetAt)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
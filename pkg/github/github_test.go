@@ -0,0 +1,61 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWaitForRateLimit(t *testing.T) {
+	stopClock(t)
+
+	tests := []struct {
+		name      string
+		rateLimit RateLimit
+		wait      time.Duration
+	}{
+		{
+			name:      "enough remaining",
+			rateLimit: RateLimit{Cost: 1, Remaining: rateLimitReserve, ResetAt: clock.Add(time.Hour).Format(time.RFC3339)},
+		},
+		{
+			name:      "already reset",
+			rateLimit: RateLimit{Cost: 1, Remaining: 0, ResetAt: clock.Add(-time.Second).Format(time.RFC3339)},
+		},
+		{
+			name:      "invalid reset time",
+			rateLimit: RateLimit{Cost: 1, Remaining: 0, ResetAt: "soon"},
+		},
+		{
+			name:      "nearly exhausted",
+			rateLimit: RateLimit{Cost: 5, Remaining: 9, ResetAt: clock.Add(100 * time.Millisecond).Format(time.RFC3339Nano)},
+			wait:      100 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start := time.Now()
+			if err := waitForRateLimit(context.Background(), test.rateLimit); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			elapsed := time.Since(start)
+			if elapsed < test.wait || elapsed > test.wait+time.Second {
+				t.Errorf("waited %v, want %v", elapsed, test.wait)
+			}
+		})
+	}
+}
+
+func TestWaitForRateLimitStopsWaitingWhenCancelled(t *testing.T) {
+	stopClock(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	rateLimit := RateLimit{Cost: 1, Remaining: 0, ResetAt: clock.Add(time.Hour).Format(time.RFC3339)}
+	if err := waitForRateLimit(ctx, rateLimit); err != context.DeadlineExceeded {
+		t.Errorf("waitForRateLimit() = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,128 @@
+package github
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	gitHubAPIHost = "api.github.com"
+	gitHubWebURL  = "https://github.com"
+)
+
+type (
+
+	// Option configures how a client or app connects to the GitHub API.
+	Option func(*options)
+
+	options struct {
+		baseURL string
+		proxy   *url.URL
+		rootCAs *x509.CertPool
+		timeout time.Duration
+	}
+)
+
+// WithBaseURL sets the base URL of the GitHub API. For GitHub Enterprise Server this is the URL of the instance, e.g.
+// https://github.example.com, whose GraphQL API is served at /api/graphql and REST API at /api/v3. The default is https://api.github.com.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithProxy sets the HTTP proxy requests are sent through. By default the proxy is taken from the HTTPS_PROXY and NO_PROXY environment variables.
+func WithProxy(proxy *url.URL) Option {
+	return func(o *options) {
+		o.proxy = proxy
+	}
+}
+
+// WithRootCAs sets the certificate authorities trusted to verify the GitHub API's TLS certificate, such as those of a corporate
+// certificate authority. By default the system's certificate authorities are trusted.
+func WithRootCAs(rootCAs *x509.CertPool) Option {
+	return func(o *options) {
+		o.rootCAs = rootCAs
+	}
+}
+
+// WithTimeout sets the maximum time to wait to connect to the GitHub API and for the response headers of each request. Requests that
+// time out are retried in the same way as other transient failures. By default there is no timeout other than the context's.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// endpoints returns the GraphQL endpoint and REST API base URL for the configured base URL.
+func (o options) endpoints() (graphQL, rest string) {
+	baseURL := strings.TrimSuffix(o.baseURL, "/")
+	if len(baseURL) == 0 {
+		return endpoint, restEndpoint
+	}
+
+	if u, err := url.Parse(baseURL); err == nil && u.Host == gitHubAPIHost {
+		return baseURL + "/graphql", baseURL
+	}
+
+	baseURL = strings.TrimSuffix(baseURL, "/api")
+	return baseURL + "/api/graphql", baseURL + "/api/v3"
+}
+
+// WebURL returns the URL of the GitHub web interface for the passed API base URL, as accepted by WithBaseURL. An empty base URL
+// returns the URL of github.com.
+func WebURL(baseURL string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	if len(baseURL) == 0 {
+		return gitHubWebURL
+	}
+
+	if u, err := url.Parse(baseURL); err == nil && u.Host == gitHubAPIHost {
+		return gitHubWebURL
+	}
+
+	return strings.TrimSuffix(baseURL, "/api")
+}
+
+// httpClient returns an HTTP client using the configured proxy, certificate authorities and timeout, which retries requests failing
+// with transient errors or because of rate limiting.
+func (o options) httpClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if o.proxy != nil {
+		transport.Proxy = http.ProxyURL(o.proxy)
+	}
+
+	if o.rootCAs != nil {
+		transport.TLSClientConfig = &tls.Config{RootCAs: o.rootCAs}
+	}
+
+	if o.timeout > 0 {
+		dialer := &net.Dialer{
+			Timeout:   o.timeout,
+			KeepAlive: 30 * time.Second,
+		}
+
+		transport.DialContext = dialer.DialContext
+		transport.TLSHandshakeTimeout = o.timeout
+		transport.ResponseHeaderTimeout = o.timeout
+	}
+
+	return &http.Client{
+		Transport: newRetryTransport(transport),
+	}
+}
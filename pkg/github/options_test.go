@@ -0,0 +1,48 @@
+package github
+
+import "testing"
+
+func TestEndpoints(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		graphQL string
+		rest    string
+	}{
+		{baseURL: "", graphQL: "https://api.github.com/graphql", rest: "https://api.github.com"},
+		{baseURL: "https://api.github.com", graphQL: "https://api.github.com/graphql", rest: "https://api.github.com"},
+		{baseURL: "https://api.github.com/", graphQL: "https://api.github.com/graphql", rest: "https://api.github.com"},
+		{baseURL: "https://ghe.example.com", graphQL: "https://ghe.example.com/api/graphql", rest: "https://ghe.example.com/api/v3"},
+		{baseURL: "https://ghe.example.com/", graphQL: "https://ghe.example.com/api/graphql", rest: "https://ghe.example.com/api/v3"},
+		{baseURL: "https://ghe.example.com/api", graphQL: "https://ghe.example.com/api/graphql", rest: "https://ghe.example.com/api/v3"},
+		{baseURL: "https://ghe.example.com/api/", graphQL: "https://ghe.example.com/api/graphql", rest: "https://ghe.example.com/api/v3"},
+		{baseURL: "http://127.0.0.1:8080", graphQL: "http://127.0.0.1:8080/api/graphql", rest: "http://127.0.0.1:8080/api/v3"},
+	}
+
+	for _, test := range tests {
+		graphQL, rest := newOptions([]Option{WithBaseURL(test.baseURL)}).endpoints()
+		if graphQL != test.graphQL || rest != test.rest {
+			t.Errorf("endpoints() for %q = %q, %q, want %q, %q", test.baseURL, graphQL, rest, test.graphQL, test.rest)
+		}
+	}
+}
+
+func TestWebURL(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		want    string
+	}{
+		{baseURL: "", want: "https://github.com"},
+		{baseURL: "https://api.github.com", want: "https://github.com"},
+		{baseURL: "https://api.github.com/", want: "https://github.com"},
+		{baseURL: "https://ghe.example.com", want: "https://ghe.example.com"},
+		{baseURL: "https://ghe.example.com/", want: "https://ghe.example.com"},
+		{baseURL: "https://ghe.example.com/api", want: "https://ghe.example.com"},
+		{baseURL: "https://ghe.example.com/api/", want: "https://ghe.example.com"},
+	}
+
+	for _, test := range tests {
+		if got := WebURL(test.baseURL); got != test.want {
+			t.Errorf("WebURL(%q) = %q, want %q", test.baseURL, got, test.want)
+		}
+	}
+}
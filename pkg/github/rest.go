@@ -0,0 +1,223 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	restEndpoint = "https://api.github.com"
+	restPageSize = 100
+)
+
+// linkNextPattern matches the URL of the next page in a Link header.
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// StreamOrganizationRESTAuditEvents calls the passed function with each page of events from the REST audit log of the passed organisation
+// matching the passed search phrase (every event if it is empty) that were created at or after the passed RFC 3339 timestamp (or all
+// events if it is empty). Unlike the GraphQL audit log, the REST audit log includes every action, including Git events. Events are
+// requested in ascending creation order.
+func (c Client) StreamOrganizationRESTAuditEvents(ctx context.Context, organisation, since, phrase string, fn PageFunc) error {
+	return c.streamRESTAuditEvents(ctx, "orgs/"+url.PathEscape(organisation)+"/audit-log", since, phrase, withDefaultOrganization(organisation, fn))
+}
+
+// StreamEnterpriseRESTAuditEvents calls the passed function with each page of events from the REST audit log of the passed enterprise,
+// which covers every organisation in the enterprise. See StreamOrganizationRESTAuditEvents.
+func (c Client) StreamEnterpriseRESTAuditEvents(ctx context.Context, enterprise, since, phrase string, fn PageFunc) error {
+	return c.streamRESTAuditEvents(ctx, "enterprises/"+url.PathEscape(enterprise)+"/audit-log", since, phrase, fn)
+}
+
+func (c Client) streamRESTAuditEvents(ctx context.Context, path, since, phrase string, fn PageFunc) error {
+	if len(since) > 0 {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse high-water mark '%s'", since)
+		}
+
+		// As with the GraphQL audit log, the search syntax only has second precision so the comparison is inclusive.
+		phrase = strings.TrimSpace(fmt.Sprintf("%s created:>=%s", phrase, t.UTC().Format("2006-01-02T15:04:05Z")))
+	}
+
+	query := url.Values{}
+	query.Set("include", "all")
+	query.Set("order", "asc")
+	query.Set("per_page", strconv.Itoa(restPageSize))
+	if len(phrase) > 0 {
+		query.Set("phrase", phrase)
+	}
+
+	next := fmt.Sprintf("%s/%s?%s", c.restURL, path, query.Encode())
+
+	for page := 1; len(next) > 0; page++ {
+		var events []AuditEvent
+		var err error
+		var rateLimit RateLimit
+
+		events, next, rateLimit, err = c.fetchRESTAuditPage(ctx, next)
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch page %d of REST audit log entries", page)
+		}
+
+		if err := fn(events); err != nil {
+			return errors.Wrapf(err, "failed to process page %d of REST audit log entries", page)
+		}
+
+		if len(next) > 0 {
+			if err := waitForRateLimit(ctx, rateLimit); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// fetchRESTAuditPage fetches the page of audit log events at the passed URL, returning the events, the URL of the next page (empty if
+// this is the last page) and the rate limit status.
+func (c Client) fetchRESTAuditPage(ctx context.Context, pageURL string) ([]AuditEvent, string, RateLimit, error) {
+	var rateLimit RateLimit
+
+	req, err := http.NewRequest(http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, "", rateLimit, err
+	}
+
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return nil, "", rateLimit, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, "", rateLimit, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", rateLimit, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", rateLimit, fmt.Errorf("GitHub API responded with status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var entries []json.RawMessage
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, "", rateLimit, errors.Wrap(err, "failed to parse REST audit log entries")
+	}
+
+	events := make([]AuditEvent, len(entries))
+	for i, raw := range entries {
+		var entry map[string]interface{}
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return nil, "", rateLimit, errors.Wrap(err, "failed to parse REST audit log entry")
+		}
+
+		n := restAuditEvent(entry)
+		n.Raw = raw
+		events[i] = eventForNode(n)
+	}
+
+	// Each REST request costs one from the rate limit budget.
+	rateLimit.Cost = 1
+	rateLimit.Remaining, err = strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		rateLimit.Remaining = rateLimitReserve
+	}
+
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rateLimit.ResetAt = time.Unix(reset, 0).UTC().Format(time.RFC3339)
+	}
+
+	next := ""
+	if match := linkNextPattern.FindStringSubmatch(resp.Header.Get("Link")); match != nil {
+		next = match[1]
+	}
+
+	return events, next, rateLimit, nil
+}
+
+// restAuditEvent normalises the passed REST audit log entry, which is a flat JSON object whose fields vary by action, into an event.
+func restAuditEvent(entry map[string]interface{}) Node {
+	return Node{
+		ID:                   restString(entry, "_document_id"),
+		Action:               restString(entry, "action"),
+		Actor:                restActor(restString(entry, "actor")),
+		BlockedUser:          restActor(restString(entry, "blocked_user")),
+		CreatedAt:            restTime(entry, "@timestamp", "created_at"),
+		Email:                restString(entry, "email", "invitee_email"),
+		EnterpriseSlug:       restString(entry, "business"),
+		MergeType:            restString(entry, "merge_type"),
+		OauthApplicationName: restString(entry, "oauth_application_name", "oauth_application"),
+		OrganizationName:     restString(entry, "org"),
+		ParentTeamName:       restString(entry, "parent_team"),
+		Permission:           restString(entry, "permission"),
+		PermissionWas:        restString(entry, "old_permission"),
+		RepositoryName:       restString(entry, "repo", "repository"),
+		TeamName:             restString(entry, "team"),
+		TopicName:            restString(entry, "topic"),
+		User:                 restActor(restString(entry, "user")),
+		Visibility:           restString(entry, "visibility"),
+	}
+}
+
+// restActor returns the actor with the passed login. The REST audit log only gives the login of users and bots.
+func restActor(login string) Actor {
+	switch {
+	case len(login) == 0:
+		return Actor{}
+	case strings.HasSuffix(login, "[bot]"):
+		return Actor{Type: "Bot", Login: login}
+	}
+
+	return Actor{Type: "User", Login: login}
+}
+
+// restString returns the first of the passed fields of the passed entry that is a non-empty string, or the first element of a
+// non-empty array of strings (as enterprise-wide entries give their organisations).
+func restString(entry map[string]interface{}, fields ...string) string {
+	for _, field := range fields {
+		switch v := entry[field].(type) {
+		case string:
+			if len(v) > 0 {
+				return v
+			}
+
+		case []interface{}:
+			if len(v) > 0 {
+				if s, ok := v[0].(string); ok && len(s) > 0 {
+					return s
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// restTime returns the first of the passed fields of the passed entry that is a timestamp in milliseconds since the epoch, formatted
+// using RFC 3339.
+func restTime(entry map[string]interface{}, fields ...string) string {
+	for _, field := range fields {
+		if ms, ok := entry[field].(float64); ok && ms > 0 {
+			return time.Unix(0, int64(ms)*int64(time.Millisecond)).UTC().Format("2006-01-02T15:04:05.000Z07:00")
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,97 @@
+package github
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ONSdigital/github-auditor/pkg/retry"
+)
+
+var (
+
+	// now and backoff return the current time and the delay before retrying a failed request, and are replaced in tests to shorten waits.
+	now     = time.Now
+	backoff = retry.Backoff
+)
+
+// retryTransport is an http.RoundTripper that retries requests failing with transient errors, using jittered exponential backoff.
+// Rate limit responses are retried after the delay GitHub asks for in the Retry-After or X-RateLimit-Reset headers.
+type retryTransport struct {
+	transport http.RoundTripper
+}
+
+func newRetryTransport(transport http.RoundTripper) *retryTransport {
+	return &retryTransport{transport: transport}
+}
+
+// RoundTrip executes the passed HTTP request, retrying it up to retry.MaxAttempts times.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	// A request body can only be sent again if it can be recreated.
+	replayable := req.Body == nil || req.GetBody != nil
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.transport.RoundTrip(attemptReq)
+
+		delay, retryable := retryDelay(resp, err, attempt)
+		if !retryable || !replayable || attempt == retry.MaxAttempts {
+			return resp, err
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+			log.Printf("GitHub API request failed with status %s, retrying in %v (attempt %d of %d)", resp.Status, delay, attempt, retry.MaxAttempts)
+		} else {
+			log.Printf("GitHub API request failed: %v, retrying in %v (attempt %d of %d)", err, delay, attempt, retry.MaxAttempts)
+		}
+
+		if err := retry.Wait(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retrying a request that returned the passed response and error, and whether it should be retried at all.
+func retryDelay(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err != nil {
+		return backoff(attempt), true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusForbidden:
+
+		// Secondary rate limits and abuse detection responses include a Retry-After header giving the number of seconds to wait.
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+
+		// Exhausting the primary rate limit returns a 403 with no remaining requests and the UTC epoch second the limit resets at.
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+				return time.Unix(reset, 0).Sub(now()) + time.Second, true
+			}
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return backoff(attempt), true
+		}
+
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return backoff(attempt), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,213 @@
+package github
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/github-auditor/pkg/retry"
+)
+
+// clock is the time the clock is stopped at by stopClock.
+var clock = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// stopClock stops the clock used for rate limit waits at clock and makes backoff delays a millisecond per attempt, until the test finishes.
+func stopClock(t *testing.T) {
+	now = func() time.Time { return clock }
+	backoff = func(attempt int) time.Duration { return time.Duration(attempt) * time.Millisecond }
+	t.Cleanup(func() {
+		now = time.Now
+		backoff = retry.Backoff
+	})
+}
+
+// response is a response returned by a test server.
+type response struct {
+	status  int
+	headers map[string]string
+}
+
+// newRetryServer returns a server responding to each request with the next of the passed responses, repeating the last, and a
+// pointer to the bodies of the requests received.
+func newRetryServer(t *testing.T, responses ...response) (*httptest.Server, *[]string) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read request body: %v", err)
+		}
+
+		resp := responses[len(responses)-1]
+		if len(bodies) < len(responses) {
+			resp = responses[len(bodies)]
+		}
+
+		bodies = append(bodies, string(body))
+		for name, value := range resp.headers {
+			w.Header().Set(name, value)
+		}
+
+		w.WriteHeader(resp.status)
+	}))
+
+	t.Cleanup(server.Close)
+	return server, &bodies
+}
+
+func TestRetryTransport(t *testing.T) {
+	stopClock(t)
+	reset := strconv.FormatInt(clock.Add(-time.Second).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		responses []response
+		requests  int
+		status    int
+	}{
+		{
+			name:      "server errors",
+			responses: []response{{status: http.StatusBadGateway}, {status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			requests:  3,
+			status:    http.StatusOK,
+		},
+		{
+			name:      "persistent server error",
+			responses: []response{{status: http.StatusInternalServerError}},
+			requests:  retry.MaxAttempts,
+			status:    http.StatusInternalServerError,
+		},
+		{
+			name:      "secondary rate limit",
+			responses: []response{{status: http.StatusForbidden, headers: map[string]string{"Retry-After": "0"}}, {status: http.StatusOK}},
+			requests:  2,
+			status:    http.StatusOK,
+		},
+		{
+			name: "primary rate limit",
+			responses: []response{
+				{status: http.StatusForbidden, headers: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": reset}},
+				{status: http.StatusOK},
+			},
+			requests: 2,
+			status:   http.StatusOK,
+		},
+		{
+			name:      "rate limited without headers",
+			responses: []response{{status: http.StatusTooManyRequests}, {status: http.StatusOK}},
+			requests:  2,
+			status:    http.StatusOK,
+		},
+		{
+			name:      "forbidden",
+			responses: []response{{status: http.StatusForbidden}},
+			requests:  1,
+			status:    http.StatusForbidden,
+		},
+		{
+			name:      "not found",
+			responses: []response{{status: http.StatusNotFound}},
+			requests:  1,
+			status:    http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, bodies := newRetryServer(t, test.responses...)
+			client := &http.Client{Transport: newRetryTransport(http.DefaultTransport)}
+
+			resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"query":"{}"}`))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			resp.Body.Close()
+			if resp.StatusCode != test.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, test.status)
+			}
+
+			if len(*bodies) != test.requests {
+				t.Errorf("made %d requests, want %d", len(*bodies), test.requests)
+			}
+
+			// The request body is sent again with each retry.
+			for i, body := range *bodies {
+				if body != `{"query":"{}"}` {
+					t.Errorf("request %d body = %q", i+1, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	stopClock(t)
+
+	tests := []struct {
+		name      string
+		status    int
+		headers   map[string]string
+		err       error
+		delay     time.Duration
+		retryable bool
+	}{
+		{name: "network error", err: errors.New("connection reset"), delay: 3 * time.Millisecond, retryable: true},
+		{name: "bad gateway", status: http.StatusBadGateway, delay: 3 * time.Millisecond, retryable: true},
+		{name: "service unavailable", status: http.StatusServiceUnavailable, delay: 3 * time.Millisecond, retryable: true},
+		{
+			name:      "secondary rate limit",
+			status:    http.StatusForbidden,
+			headers:   map[string]string{"Retry-After": "30"},
+			delay:     30 * time.Second,
+			retryable: true,
+		},
+		{
+			name:      "secondary rate limit with too many requests",
+			status:    http.StatusTooManyRequests,
+			headers:   map[string]string{"Retry-After": "5"},
+			delay:     5 * time.Second,
+			retryable: true,
+		},
+		{
+			name:   "primary rate limit",
+			status: http.StatusForbidden,
+			headers: map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     strconv.FormatInt(clock.Add(time.Minute).Unix(), 10),
+			},
+			delay:     time.Minute + time.Second,
+			retryable: true,
+		},
+		{
+			name:    "requests remaining",
+			status:  http.StatusForbidden,
+			headers: map[string]string{"X-RateLimit-Remaining": "10", "X-RateLimit-Reset": strconv.FormatInt(clock.Unix(), 10)},
+		},
+		{name: "too many requests", status: http.StatusTooManyRequests, delay: 3 * time.Millisecond, retryable: true},
+		{name: "forbidden", status: http.StatusForbidden},
+		{name: "unauthorised", status: http.StatusUnauthorized},
+		{name: "ok", status: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var resp *http.Response
+			if test.err == nil {
+				resp = &http.Response{StatusCode: test.status, Header: make(http.Header)}
+				for name, value := range test.headers {
+					resp.Header.Set(name, value)
+				}
+			}
+
+			delay, retryable := retryDelay(resp, test.err, 3)
+			if delay != test.delay || retryable != test.retryable {
+				t.Errorf("retryDelay() = %v, %t, want %v, %t", delay, retryable, test.delay, test.retryable)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const signaturePrefix = "sha256="
+
+// webhookActions are the audit log actions that webhooks are mapped to by WebhookEvents.
+var webhookActions = map[string]bool{
+	"org.add_member":         true,
+	"org.remove_member":      true,
+	"org.invite_member":      true,
+	"repo.add_member":        true,
+	"repo.remove_member":     true,
+	"team.add_member":        true,
+	"team.remove_member":     true,
+	"team.add_repository":    true,
+	"team.remove_repository": true,
+	"repo.create":            true,
+	"repo.destroy":           true,
+	"repo.archived":          true,
+	"repo.access":            true,
+}
+
+type (
+	webhookAccount struct {
+		Login string `json:"login"`
+		Type  string `json:"type"`
+	}
+
+	webhookRepository struct {
+		FullName   string `json:"full_name"`
+		Private    bool   `json:"private"`
+		Visibility string `json:"visibility"`
+	}
+
+	webhookTeam struct {
+		Slug string `json:"slug"`
+	}
+
+	// webhookPayload holds the fields of the organisation webhook payloads that are mapped to audit log events.
+	webhookPayload struct {
+		Action       string             `json:"action"`
+		Sender       webhookAccount     `json:"sender"`
+		Organization webhookAccount     `json:"organization"`
+		Repository   *webhookRepository `json:"repository"`
+		Team         *webhookTeam       `json:"team"`
+		Member       *webhookAccount    `json:"member"`
+		User         *webhookAccount    `json:"user"`
+		Membership   *struct {
+			User webhookAccount `json:"user"`
+			Role string         `json:"role"`
+		} `json:"membership"`
+		Invitation *struct {
+			Login string `json:"login"`
+			Email string `json:"email"`
+		} `json:"invitation"`
+	}
+)
+
+// ValidWebhookSignature returns whether the passed X-Hub-Signature-256 header value is the HMAC-SHA256 signature of the passed webhook
+// payload using the passed secret.
+func ValidWebhookSignature(secret, payload []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	sum, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hmac.Equal(sum, mac.Sum(nil))
+}
+
+// WebhookAction returns whether webhooks are mapped to the passed audit log action, in which case events of the action can be received
+// from both sources but only have the fields webhooks give when received by webhook.
+func WebhookAction(action string) bool {
+	return webhookActions[action]
+}
+
+// WebhookEvents returns the audit log events equivalent to the passed organisation webhook, identified by its X-GitHub-Event and
+// X-GitHub-Delivery headers and received at the passed time. The organization, member, membership, team, repository and public events
+// are supported; other events and actions without an equivalent audit log action return no events. Webhooks don't include the ID of
+// the audit log entry, so the returned events are given IDs derived from the delivery ID. The payload is kept as the events' raw JSON.
+func WebhookEvents(eventType, deliveryID string, payload []byte, receivedAt time.Time) ([]AuditEvent, error) {
+	var p webhookPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s webhook payload", eventType)
+	}
+
+	e := Node{
+		ID:               "webhook-" + deliveryID,
+		Actor:            webhookActor(p.Sender),
+		CreatedAt:        receivedAt.UTC().Format(time.RFC3339),
+		OrganizationName: p.Organization.Login,
+		Raw:              payload,
+	}
+
+	if p.Repository != nil {
+		e.RepositoryName = p.Repository.FullName
+	}
+
+	if p.Team != nil {
+		e.TeamName = p.Organization.Login + "/" + p.Team.Slug
+	}
+
+	switch eventType + "." + p.Action {
+	case "organization.member_added":
+		e.Action = "org.add_member"
+		e.User = webhookMembershipUser(p)
+		e.Permission = webhookMembershipPermission(p)
+	case "organization.member_removed":
+		e.Action = "org.remove_member"
+		e.User = webhookMembershipUser(p)
+	case "organization.member_invited":
+		e.Action = "org.invite_member"
+		if p.User != nil {
+			e.User = webhookActor(*p.User)
+		} else if p.Invitation != nil {
+			e.User = Actor{Type: "User", Login: p.Invitation.Login}
+			e.Email = p.Invitation.Email
+		}
+
+	case "member.added":
+		e.Action = "repo.add_member"
+		e.User = webhookMember(p)
+	case "member.removed":
+		e.Action = "repo.remove_member"
+		e.User = webhookMember(p)
+
+	case "membership.added":
+		e.Action = "team.add_member"
+		e.User = webhookMember(p)
+	case "membership.removed":
+		e.Action = "team.remove_member"
+		e.User = webhookMember(p)
+
+	case "team.added_to_repository":
+		e.Action = "team.add_repository"
+	case "team.removed_from_repository":
+		e.Action = "team.remove_repository"
+
+	case "repository.created":
+		e.Action = "repo.create"
+		e.Visibility = webhookVisibility(p.Repository)
+	case "repository.deleted":
+		e.Action = "repo.destroy"
+	case "repository.archived":
+		e.Action = "repo.archived"
+	case "repository.publicized", "public.":
+		e.Action = "repo.access"
+		e.Visibility = "public"
+	case "repository.privatized":
+		e.Action = "repo.access"
+		e.Visibility = "private"
+
+	default:
+		return nil, nil
+	}
+
+	return []AuditEvent{eventForNode(e)}, nil
+}
+
+func webhookActor(account webhookAccount) Actor {
+	actor := Actor{
+		Type:  account.Type,
+		Login: account.Login,
+	}
+
+	// Organisation actors are identified by name in the audit log.
+	if account.Type == "Organization" {
+		actor.Name = account.Login
+	}
+
+	return actor
+}
+
+func webhookMember(p webhookPayload) Actor {
+	if p.Member == nil {
+		return Actor{}
+	}
+
+	return webhookActor(*p.Member)
+}
+
+func webhookMembershipUser(p webhookPayload) Actor {
+	if p.Membership == nil {
+		return Actor{}
+	}
+
+	return webhookActor(p.Membership.User)
+}
+
+// webhookMembershipPermission returns the audit log permission (ADMIN or READ) equivalent to the role of the membership in the passed
+// payload.
+func webhookMembershipPermission(p webhookPayload) string {
+	switch {
+	case p.Membership == nil:
+		return ""
+	case p.Membership.Role == "admin":
+		return "ADMIN"
+	}
+
+	return "READ"
+}
+
+func webhookVisibility(repository *webhookRepository) string {
+	switch {
+	case repository == nil:
+		return ""
+	case len(repository.Visibility) > 0:
+		return repository.Visibility
+	case repository.Private:
+		return "private"
+	}
+
+	return "public"
+}
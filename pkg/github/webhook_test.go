@@ -0,0 +1,153 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+func TestValidWebhookSignature(t *testing.T) {
+	secret := []byte("secret")
+	payload := []byte(`{"action":"added"}`)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		payload   []byte
+		signature string
+		want      bool
+	}{
+		{"valid signature", secret, payload, signature, true},
+		{"wrong secret", []byte("other"), payload, signature, false},
+		{"modified payload", secret, []byte(`{"action":"removed"}`), signature, false},
+		{"missing prefix", secret, payload, signature[len(signaturePrefix):], false},
+		{"SHA-1 signature", secret, payload, "sha1=" + signature[len(signaturePrefix):], false},
+		{"invalid hex", secret, payload, "sha256=xyz", false},
+		{"empty signature", secret, payload, "", false},
+	}
+
+	for _, test := range tests {
+		if got := ValidWebhookSignature(test.secret, test.payload, test.signature); got != test.want {
+			t.Errorf("%s: ValidWebhookSignature = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestWebhookEvents(t *testing.T) {
+	receivedAt := time.Date(2020, 6, 1, 12, 0, 0, 0, time.FixedZone("BST", 3600))
+	sender := `"sender": {"login": "alice", "type": "User"}, "organization": {"login": "ons"}`
+
+	tests := []struct {
+		eventType string
+		payload   string
+		want      Node
+	}{
+		{
+			eventType: "organization",
+			payload:   `{"action": "member_added", ` + sender + `, "membership": {"user": {"login": "bob", "type": "User"}, "role": "admin"}}`,
+			want:      Node{Action: "org.add_member", User: Actor{Type: "User", Login: "bob"}, Permission: "ADMIN"},
+		},
+		{
+			eventType: "organization",
+			payload:   `{"action": "member_removed", ` + sender + `, "membership": {"user": {"login": "bob", "type": "User"}, "role": "member"}}`,
+			want:      Node{Action: "org.remove_member", User: Actor{Type: "User", Login: "bob"}},
+		},
+		{
+			eventType: "organization",
+			payload:   `{"action": "member_invited", ` + sender + `, "invitation": {"email": "bob@example.com"}}`,
+			want:      Node{Action: "org.invite_member", User: Actor{Type: "User"}, Email: "bob@example.com"},
+		},
+		{
+			eventType: "member",
+			payload:   `{"action": "added", ` + sender + `, "repository": {"full_name": "ons/app"}, "member": {"login": "bob", "type": "User"}}`,
+			want:      Node{Action: "repo.add_member", User: Actor{Type: "User", Login: "bob"}, RepositoryName: "ons/app"},
+		},
+		{
+			eventType: "membership",
+			payload:   `{"action": "removed", ` + sender + `, "team": {"slug": "platform"}, "member": {"login": "bob", "type": "User"}}`,
+			want:      Node{Action: "team.remove_member", User: Actor{Type: "User", Login: "bob"}, TeamName: "ons/platform"},
+		},
+		{
+			eventType: "team",
+			payload:   `{"action": "added_to_repository", ` + sender + `, "team": {"slug": "platform"}, "repository": {"full_name": "ons/app"}}`,
+			want:      Node{Action: "team.add_repository", TeamName: "ons/platform", RepositoryName: "ons/app"},
+		},
+		{
+			eventType: "repository",
+			payload:   `{"action": "created", ` + sender + `, "repository": {"full_name": "ons/app", "private": true}}`,
+			want:      Node{Action: "repo.create", RepositoryName: "ons/app", Visibility: "private"},
+		},
+		{
+			eventType: "repository",
+			payload:   `{"action": "created", ` + sender + `, "repository": {"full_name": "ons/app", "private": true, "visibility": "internal"}}`,
+			want:      Node{Action: "repo.create", RepositoryName: "ons/app", Visibility: "internal"},
+		},
+		{
+			eventType: "repository",
+			payload:   `{"action": "privatized", ` + sender + `, "repository": {"full_name": "ons/app"}}`,
+			want:      Node{Action: "repo.access", RepositoryName: "ons/app", Visibility: "private"},
+		},
+		{
+			eventType: "public",
+			payload:   `{` + sender + `, "repository": {"full_name": "ons/app"}}`,
+			want:      Node{Action: "repo.access", RepositoryName: "ons/app", Visibility: "public"},
+		},
+	}
+
+	for _, test := range tests {
+		events, err := WebhookEvents(test.eventType, "delivery", []byte(test.payload), receivedAt)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.want.Action, err)
+			continue
+		}
+
+		if len(events) != 1 {
+			t.Errorf("%s: got %d events, want 1", test.want.Action, len(events))
+			continue
+		}
+
+		got := events[0].Node()
+		want := test.want
+		want.ID = "webhook-delivery"
+		want.Actor = Actor{Type: "User", Login: "alice"}
+		want.CreatedAt = "2020-06-01T11:00:00Z"
+		want.OrganizationName = "ons"
+
+		if got.Action != want.Action || got.ID != want.ID || got.Actor != want.Actor || got.User != want.User ||
+			got.CreatedAt != want.CreatedAt || got.OrganizationName != want.OrganizationName || got.RepositoryName != want.RepositoryName ||
+			got.TeamName != want.TeamName || got.Email != want.Email || got.Visibility != want.Visibility || got.Permission != want.Permission {
+			t.Errorf("%s: got %+v, want %+v", want.Action, got, want)
+		}
+
+		if string(got.Raw) != test.payload {
+			t.Errorf("%s: raw JSON isn't the payload", want.Action)
+		}
+
+		if !WebhookAction(got.Action) {
+			t.Errorf("%s: WebhookAction returned false for a mapped action", want.Action)
+		}
+	}
+}
+
+func TestWebhookEventsIgnoresUnmappedActions(t *testing.T) {
+	for _, eventType := range []string{"push", "repository", "organization"} {
+		events, err := WebhookEvents(eventType, "delivery", []byte(`{"action": "edited"}`), time.Now())
+		if err != nil || len(events) != 0 {
+			t.Errorf("%s.edited: got %d events and error %v, want none", eventType, len(events), err)
+		}
+	}
+
+	if WebhookAction("org.update_member") {
+		t.Error("WebhookAction returned true for an action webhooks aren't mapped to")
+	}
+
+	if _, err := WebhookEvents("member", "delivery", []byte(`{`), time.Now()); err == nil {
+		t.Error("expected an error for an invalid payload")
+	}
+}
@@ -2,9 +2,10 @@ package firestore
 
 import (
 	"context"
-	"log"
 
 	"cloud.google.com/go/firestore"
+	"github.com/ONSdigital/github-auditor/pkg/state"
+	"github.com/pkg/errors"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -16,80 +17,140 @@ type (
 	Client struct {
 		projectID       string
 		credentialsFile string
-		context         *context.Context
 		client          *firestore.Client
 	}
 )
 
-const firestoreCollection = "github-auditor"
+const (
+	firestoreCollection      = "github-auditor"
+	firestoreStateCollection = "github-auditor-state"
+	maxBatchWrites           = 500
+)
 
-// NewClient instantiates a new Firestore client for the passed GCP project.
-func NewClient(projectID string) *Client {
-	ctx := context.Background()
+// NewClient instantiates a new Firestore client for the passed GCP project. The passed context is only used while connecting.
+func NewClient(ctx context.Context, projectID string) (*Client, error) {
 	client, err := firestore.NewClient(ctx, projectID)
 	if err != nil {
-		log.Fatalf("Failed to instantiate Firestore client in project %s: %v", projectID, err)
+		return nil, errors.Wrapf(err, "failed to instantiate Firestore client in project %s", projectID)
 	}
 
 	return &Client{
 		projectID: projectID,
-		context:   &ctx,
 		client:    client,
-	}
+	}, nil
 }
 
 // NewClientWithCredentials instantiates a new Firestore client for the passed GCP project using the passed path to a JSON service account key file.
-func NewClientWithCredentials(projectID, credentialsFile string) *Client {
-	ctx := context.Background()
+// The passed context is only used while connecting.
+func NewClientWithCredentials(ctx context.Context, projectID, credentialsFile string) (*Client, error) {
 	client, err := firestore.NewClient(ctx, projectID, option.WithCredentialsFile(credentialsFile))
 	if err != nil {
-		log.Fatalf("Failed to instantiate Firestore client in project %s using credentials file %s: %v", projectID, credentialsFile, err)
+		return nil, errors.Wrapf(err, "failed to instantiate Firestore client in project %s using credentials file %s", projectID, credentialsFile)
 	}
 
 	return &Client{
 		projectID:       projectID,
 		credentialsFile: credentialsFile,
-		context:         &ctx,
 		client:          client,
-	}
+	}, nil
 }
 
-// DocExists returns whether the Firestore document with the passed ID containing the passed timestamp and action exists.
-func (c Client) DocExists(id, timestamp, action string) bool {
-	snapshot, err := c.client.Collection(firestoreCollection).Doc(id).Get(*c.context)
-	if status.Code(err) == codes.NotFound {
-		return false
+// DocsExist returns, for each of the passed documents, whether a Firestore document with the same ID containing the same timestamp and action exists.
+// All the documents are retrieved in a single batched read.
+func (c Client) DocsExist(ctx context.Context, docs []state.Doc) ([]bool, error) {
+	exists := make([]bool, len(docs))
+	if len(docs) == 0 {
+		return exists, nil
 	}
 
-	if snapshot == nil {
-		return false
+	refs := make([]*firestore.DocumentRef, len(docs))
+	for i, doc := range docs {
+		refs[i] = c.client.Collection(firestoreCollection).Doc(doc.ID)
 	}
 
-	data := snapshot.Data()
-	if data == nil {
-		return false
+	snapshots, err := c.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve Firestore documents")
 	}
 
-	ts, err := snapshot.DataAt("timestamp")
-	if err != nil && status.Code(err) != codes.NotFound {
-		log.Fatalf("Failed to retrieve timestamp value from Firestore document %s/%s: %v", firestoreCollection, id, err)
+	for i, snapshot := range snapshots {
+		if snapshot == nil || !snapshot.Exists() {
+			continue
+		}
+
+		ts, err := snapshot.DataAt("timestamp")
+		if err != nil && status.Code(err) != codes.NotFound {
+			return nil, errors.Wrapf(err, "failed to retrieve timestamp value from Firestore document %s/%s", firestoreCollection, docs[i].ID)
+		}
+
+		a, err := snapshot.DataAt("action")
+		if err != nil && status.Code(err) != codes.NotFound {
+			return nil, errors.Wrapf(err, "failed to retrieve action value from Firestore document %s/%s", firestoreCollection, docs[i].ID)
+		}
+
+		exists[i] = docs[i].Timestamp == ts && docs[i].Action == a
 	}
 
-	a, err := snapshot.DataAt("action")
-	if err != nil && status.Code(err) != codes.NotFound {
-		log.Fatalf("Failed to retrieve action value from Firestore document %s/%s: %v", firestoreCollection, id, err)
+	return exists, nil
+}
+
+// SaveDocs creates or updates a Firestore document for each of the passed documents, setting its contents to the document's timestamp and action.
+// The documents are written using batched writes of up to 500 documents each, the maximum Firestore allows.
+func (c Client) SaveDocs(ctx context.Context, docs []state.Doc) error {
+	for start := 0; start < len(docs); start += maxBatchWrites {
+		end := start + maxBatchWrites
+		if end > len(docs) {
+			end = len(docs)
+		}
+
+		batch := c.client.Batch()
+		for _, doc := range docs[start:end] {
+			batch.Set(c.client.Collection(firestoreCollection).Doc(doc.ID), map[string]interface{}{
+				"timestamp": doc.Timestamp,
+				"action":    doc.Action,
+			})
+		}
+
+		if _, err := batch.Commit(ctx); err != nil {
+			return errors.Wrap(err, "failed to commit batched write of Firestore documents")
+		}
 	}
 
-	return timestamp == ts && action == a
+	return nil
 }
 
-// SaveDoc creates or updates the Firestore document with the passed ID, setting its contents to the passed timestamp and action.
-func (c Client) SaveDoc(id, timestamp, action string) error {
-	doc := c.client.Collection(firestoreCollection).Doc(id)
-	_, err := doc.Set(*c.context, map[string]interface{}{
-		"timestamp": timestamp,
-		"action":    action,
+// HighWaterMark returns the createdAt timestamp of the newest audit log event processed for the passed organisation.
+// An empty string is returned if no high-water mark has been saved yet.
+func (c Client) HighWaterMark(ctx context.Context, organisation string) (string, error) {
+	snapshot, err := c.client.Collection(firestoreStateCollection).Doc(organisation).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	createdAt, err := snapshot.DataAt("createdAt")
+	if err != nil {
+		return "", err
+	}
+
+	s, _ := createdAt.(string)
+	return s, nil
+}
+
+// SaveHighWaterMark creates or updates the Firestore document for the passed organisation, setting its high-water mark to the passed createdAt timestamp.
+func (c Client) SaveHighWaterMark(ctx context.Context, organisation, createdAt string) error {
+	doc := c.client.Collection(firestoreStateCollection).Doc(organisation)
+	_, err := doc.Set(ctx, map[string]interface{}{
+		"createdAt": createdAt,
 	})
 
 	return err
 }
+
+// Close closes the underlying Firestore client and its connection.
+func (c Client) Close() error {
+	return c.client.Close()
+}
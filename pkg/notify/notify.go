@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+type (
+
+	// Field is a titled value describing an alert, such as the actor or repository.
+	Field struct {
+		Title string `json:"title"`
+		Value string `json:"value"`
+	}
+
+	// Link is a link from an alert back to GitHub.
+	Link struct {
+		Text string `json:"text"`
+		URL  string `json:"url"`
+	}
+
+	// Alert is an alert for a GitHub audit log event, independent of how it is delivered. Text and field values use Slack's mrkdwn
+	// formatting (*bold* and _italic_), which can be converted using Markdown or PlainText.
+	Alert struct {
+		ID           string  `json:"id"`
+		Action       string  `json:"action"`
+		Category     string  `json:"category"`
+		Severity     string  `json:"severity"`
+		Organisation string  `json:"organisation,omitempty"`
+		Text         string  `json:"text"`
+		CreatedAt    string  `json:"createdAt"`
+		Timestamp    string  `json:"timestamp"`
+		Fields       []Field `json:"fields,omitempty"`
+		Links        []Link  `json:"links,omitempty"`
+
+		// Events are the JSON objects GitHub sent for the events the alert is for, with every field they included.
+		Events []json.RawMessage `json:"events,omitempty"`
+	}
+
+	// Notifier is implemented by the destinations alerts can be sent to other than Slack.
+	Notifier interface {
+
+		// Notify sends the passed alert.
+		Notify(ctx context.Context, alert Alert) error
+	}
+
+	// Filter is implemented by notifiers that only send some alerts, so that alerts they don't send aren't recorded as delivered by them.
+	Filter interface {
+
+		// Accepts returns whether the notifier sends the passed alert.
+		Accepts(alert Alert) bool
+	}
+
+	// severityFilter sends only alerts with one of a set of severities.
+	severityFilter struct {
+		notifier   Notifier
+		severities map[string]bool
+	}
+)
+
+var (
+	boldPattern   = regexp.MustCompile(`\*([^*\n]+)\*`)
+	italicPattern = regexp.MustCompile(`(^|\s)_([^_\n]+)_(\s|$)`)
+)
+
+// Title returns a one line summary of the alert, e.g. "GitHub audit log: repo.destroy (critical)", including the organisation if
+// known, e.g. "GitHub audit log: ONSdigital repo.destroy (critical)".
+func (a Alert) Title() string {
+	if len(a.Organisation) > 0 {
+		return "GitHub audit log: " + a.Organisation + " " + a.Action + " (" + a.Severity + ")"
+	}
+
+	return "GitHub audit log: " + a.Action + " (" + a.Severity + ")"
+}
+
+// DedupKey returns a key identifying the audit log event the alert is for, used to stop incident management services opening
+// duplicate incidents when an event is alerted on more than once.
+func (a Alert) DedupKey() string {
+	return "github-auditor/" + a.ID
+}
+
+// WithSeverities returns a notifier that sends alerts with one of the passed severities using the passed notifier and ignores others.
+func WithSeverities(n Notifier, severities ...string) Notifier {
+	filter := &severityFilter{
+		notifier:   n,
+		severities: make(map[string]bool),
+	}
+
+	for _, severity := range severities {
+		filter.severities[severity] = true
+	}
+
+	return filter
+}
+
+// Accepts returns whether the passed alert has one of the filter's severities.
+func (f *severityFilter) Accepts(alert Alert) bool {
+	return f.severities[alert.Severity]
+}
+
+// Notify sends the passed alert if it has one of the filter's severities.
+func (f *severityFilter) Notify(ctx context.Context, alert Alert) error {
+	if !f.Accepts(alert) {
+		return nil
+	}
+
+	return f.notifier.Notify(ctx, alert)
+}
+
+// Truncate shortens the passed text to at most the passed number of characters, ending it with an ellipsis if it was shortened.
+func Truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+
+	return string(runes[:length-1]) + "…"
+}
+
+// Markdown converts the passed mrkdwn text to CommonMark, with each line as a separate paragraph.
+func Markdown(text string) string {
+	text = boldPattern.ReplaceAllString(text, "**$1**")
+	return strings.Replace(text, "\n", "\n\n", -1)
+}
+
+// PlainText removes the mrkdwn formatting from the passed text.
+func PlainText(text string) string {
+	text = boldPattern.ReplaceAllString(text, "$1")
+	return italicPattern.ReplaceAllString(text, "$1$2$3")
+}
@@ -0,0 +1,105 @@
+package opsgenie
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/retry"
+	"github.com/pkg/errors"
+)
+
+const (
+
+	// DefaultAPIURL is the Opsgenie API URL for accounts in the US region. Accounts in the EU region use https://api.eu.opsgenie.com.
+	DefaultAPIURL = "https://api.opsgenie.com"
+
+	maxMessageLength     = 130
+	maxDescriptionLength = 15000
+)
+
+// priorities maps each severity to the priority of the Opsgenie alert.
+var priorities = map[string]string{
+	"info":     "P5",
+	"warning":  "P3",
+	"critical": "P1",
+}
+
+type (
+
+	// Alert represents an Opsgenie alert creation request.
+	Alert struct {
+		Message     string            `json:"message"`
+		Alias       string            `json:"alias,omitempty"`
+		Description string            `json:"description,omitempty"`
+		Tags        []string          `json:"tags,omitempty"`
+		Details     map[string]string `json:"details,omitempty"`
+		Entity      string            `json:"entity,omitempty"`
+		Source      string            `json:"source,omitempty"`
+		Priority    string            `json:"priority,omitempty"`
+	}
+
+	// Notifier creates an Opsgenie alert for each alert using the Opsgenie Alert API. Each alert's alias is derived from the ID of its
+	// audit log event, so an event alerted on again (for example because the state store couldn't record that the alert was sent) is
+	// deduplicated by Opsgenie while the first alert is still open.
+	Notifier struct {
+		apiURL string
+		apiKey string
+	}
+)
+
+// NewNotifier instantiates a notifier creating alerts using the passed Opsgenie API URL (DefaultAPIURL if empty) and API integration key.
+func NewNotifier(apiURL, apiKey string) *Notifier {
+	if len(apiURL) == 0 {
+		apiURL = DefaultAPIURL
+	}
+
+	return &Notifier{
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		apiKey: apiKey,
+	}
+}
+
+// Notify creates an Opsgenie alert for the passed alert.
+func (n *Notifier) Notify(ctx context.Context, alert notify.Alert) error {
+	body, err := json.Marshal(NewAlert(alert))
+	if err != nil {
+		return errors.Wrap(err, "failed marshalling Opsgenie alert to JSON")
+	}
+
+	headers := map[string]string{"Authorization": "GenieKey " + n.apiKey}
+	_, err = retry.PostJSON(ctx, nil, n.apiURL+"/v2/alerts", headers, body)
+	return errors.Wrap(err, "failed to create Opsgenie alert")
+}
+
+// NewAlert returns the Opsgenie alert creation request for the passed alert.
+func NewAlert(alert notify.Alert) Alert {
+	text := notify.PlainText(alert.Text)
+
+	details := map[string]string{
+		"action":    alert.Action,
+		"createdAt": alert.CreatedAt,
+	}
+
+	for _, field := range alert.Fields {
+		details[field.Title] = notify.PlainText(field.Value)
+	}
+
+	var description strings.Builder
+	description.WriteString(text)
+	for _, link := range alert.Links {
+		description.WriteString("\n" + link.Text + ": " + link.URL)
+	}
+
+	return Alert{
+		Message:     notify.Truncate(text, maxMessageLength),
+		Alias:       alert.DedupKey(),
+		Description: notify.Truncate(description.String(), maxDescriptionLength),
+		Tags:        []string{"github-auditor", alert.Category, alert.Action},
+		Details:     details,
+		Entity:      alert.Organisation,
+		Source:      "GitHub Auditor",
+		Priority:    priorities[alert.Severity],
+	}
+}
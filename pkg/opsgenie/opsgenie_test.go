@@ -0,0 +1,156 @@
+package opsgenie
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/retry"
+	"github.com/pkg/errors"
+)
+
+var alert = notify.Alert{
+	ID:           "MDE2OkF1ZGl0RW50cnkx",
+	Action:       "repo.destroy",
+	Category:     "repo",
+	Severity:     "critical",
+	Organisation: "ons",
+	Text:         "User *alice* deleted repo *ons/app*.",
+	CreatedAt:    "2020-06-01T12:00:00Z",
+	Fields:       []notify.Field{{Title: "Actor", Value: "User *alice*"}},
+	Links:        []notify.Link{{Text: "View Actor", URL: "https://github.com/alice"}},
+}
+
+// newTestServer returns a server responding to each request with the next of the passed statuses, and a pointer to the alerts it has
+// received.
+func newTestServer(t *testing.T, statuses ...int) (*httptest.Server, *[]Alert) {
+	var alerts []Alert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/alerts" {
+			t.Errorf("path = %q, want /v2/alerts", r.URL.Path)
+		}
+
+		if got := r.Header.Get("Authorization"); got != "GenieKey api-key" {
+			t.Errorf("Authorization header = %q", got)
+		}
+
+		var a Alert
+		if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+			t.Errorf("unexpected error decoding alert: %v", err)
+		}
+
+		status := statuses[len(statuses)-1]
+		if len(alerts) < len(statuses) {
+			status = statuses[len(alerts)]
+		}
+
+		alerts = append(alerts, a)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(status)
+		w.Write([]byte(`{"result":"Request will be processed"}`))
+	}))
+
+	return server, &alerts
+}
+
+func TestNotifyCreatesAlert(t *testing.T) {
+	server, alerts := newTestServer(t, http.StatusAccepted)
+	defer server.Close()
+
+	// A trailing slash on the API URL is ignored.
+	if err := NewNotifier(server.URL+"/", "api-key").Notify(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := Alert{
+		Message:     "User alice deleted repo ons/app.",
+		Alias:       "github-auditor/MDE2OkF1ZGl0RW50cnkx",
+		Description: "User alice deleted repo ons/app.\nView Actor: https://github.com/alice",
+		Tags:        []string{"github-auditor", "repo", "repo.destroy"},
+		Details: map[string]string{
+			"action":    "repo.destroy",
+			"createdAt": "2020-06-01T12:00:00Z",
+			"Actor":     "User alice",
+		},
+		Entity:   "ons",
+		Source:   "GitHub Auditor",
+		Priority: "P1",
+	}
+
+	if len(*alerts) != 1 || !reflect.DeepEqual((*alerts)[0], want) {
+		t.Errorf("alerts = %+v, want %+v", *alerts, want)
+	}
+}
+
+func TestNewAlert(t *testing.T) {
+	tests := []struct {
+		severity string
+		want     string
+	}{
+		{severity: "info", want: "P5"},
+		{severity: "warning", want: "P3"},
+		{severity: "critical", want: "P1"},
+	}
+
+	for _, test := range tests {
+		a := alert
+		a.Severity = test.severity
+		if got := NewAlert(a).Priority; got != test.want {
+			t.Errorf("%s alert: priority = %q, want %q", test.severity, got, test.want)
+		}
+	}
+
+	// Long text is truncated to the message's limit.
+	a := alert
+	a.Text = strings.Repeat("a", 200)
+	if length := len([]rune(NewAlert(a).Message)); length != maxMessageLength {
+		t.Errorf("message is %d characters, want %d", length, maxMessageLength)
+	}
+
+	// Alerts for the same event have the same alias and alerts for different events don't.
+	other := alert
+	other.ID = "MDE2OkF1ZGl0RW50cnky"
+	if NewAlert(a).Alias != NewAlert(alert).Alias || NewAlert(other).Alias == NewAlert(alert).Alias {
+		t.Error("aliases aren't derived from the event IDs")
+	}
+}
+
+func TestNewNotifierUsesDefaultAPIURL(t *testing.T) {
+	if n := NewNotifier("", "api-key"); n.apiURL != DefaultAPIURL {
+		t.Errorf("API URL = %q, want %q", n.apiURL, DefaultAPIURL)
+	}
+}
+
+func TestNotifyReturnsStatusError(t *testing.T) {
+	server, alerts := newTestServer(t, http.StatusUnauthorized)
+	defer server.Close()
+
+	err := NewNotifier(server.URL, "api-key").Notify(context.Background(), alert)
+
+	statusErr, ok := errors.Cause(err).(*retry.StatusError)
+	if !ok || statusErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("error = %v, want a 401 StatusError", err)
+	}
+
+	if len(*alerts) != 1 {
+		t.Errorf("received %d alerts, want 1", len(*alerts))
+	}
+}
+
+func TestNotifyRetriesServerErrors(t *testing.T) {
+	server, alerts := newTestServer(t, http.StatusServiceUnavailable, http.StatusAccepted)
+	defer server.Close()
+
+	if err := NewNotifier(server.URL, "api-key").Notify(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*alerts) != 2 {
+		t.Errorf("received %d alerts, want 2", len(*alerts))
+	}
+}
@@ -0,0 +1,117 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/retry"
+	"github.com/pkg/errors"
+)
+
+const (
+	eventsURL        = "https://events.pagerduty.com/v2/enqueue"
+	maxSummaryLength = 1024
+	defaultSource    = "github.com"
+)
+
+type (
+
+	// Payload represents the details of a PagerDuty event.
+	Payload struct {
+		Summary       string            `json:"summary"`
+		Source        string            `json:"source"`
+		Severity      string            `json:"severity"`
+		Timestamp     string            `json:"timestamp,omitempty"`
+		Component     string            `json:"component,omitempty"`
+		Group         string            `json:"group,omitempty"`
+		Class         string            `json:"class,omitempty"`
+		CustomDetails map[string]string `json:"custom_details,omitempty"`
+	}
+
+	// Link represents a link attached to a PagerDuty event.
+	Link struct {
+		Href string `json:"href"`
+		Text string `json:"text,omitempty"`
+	}
+
+	// Event represents a PagerDuty Events API v2 event.
+	Event struct {
+		RoutingKey  string  `json:"routing_key"`
+		EventAction string  `json:"event_action"`
+		DedupKey    string  `json:"dedup_key,omitempty"`
+		Client      string  `json:"client,omitempty"`
+		Payload     Payload `json:"payload"`
+		Links       []Link  `json:"links,omitempty"`
+	}
+
+	// Notifier triggers a PagerDuty incident for each alert using the Events API v2. Each alert's dedup key is derived from the ID of
+	// its audit log event, so an event alerted on again (for example because the state store couldn't record that the alert was sent)
+	// doesn't open another incident while the first is still open.
+	Notifier struct {
+		routingKey string
+		eventsURL  string
+	}
+)
+
+// NewNotifier instantiates a notifier triggering incidents using the passed PagerDuty integration (routing) key.
+func NewNotifier(routingKey string) *Notifier {
+	return &Notifier{
+		routingKey: routingKey,
+		eventsURL:  eventsURL,
+	}
+}
+
+// Notify triggers a PagerDuty incident for the passed alert.
+func (n *Notifier) Notify(ctx context.Context, alert notify.Alert) error {
+	body, err := json.Marshal(n.NewEvent(alert))
+	if err != nil {
+		return errors.Wrap(err, "failed marshalling PagerDuty event to JSON")
+	}
+
+	_, err = retry.PostJSON(ctx, nil, n.eventsURL, nil, body)
+	return errors.Wrap(err, "failed to trigger PagerDuty incident")
+}
+
+// NewEvent returns the PagerDuty trigger event for the passed alert.
+func (n *Notifier) NewEvent(alert notify.Alert) Event {
+	source := alert.Organisation
+	if len(source) == 0 {
+		source = defaultSource
+	}
+
+	details := map[string]string{
+		"action":    alert.Action,
+		"createdAt": alert.CreatedAt,
+	}
+
+	for _, field := range alert.Fields {
+		details[field.Title] = notify.PlainText(field.Value)
+	}
+
+	event := Event{
+		RoutingKey:  n.routingKey,
+		EventAction: "trigger",
+		DedupKey:    alert.DedupKey(),
+		Client:      "GitHub Auditor",
+		Payload: Payload{
+			Summary:       notify.Truncate(notify.PlainText(alert.Text), maxSummaryLength),
+			Source:        source,
+			Severity:      alert.Severity,
+			Timestamp:     alert.CreatedAt,
+			Component:     "github",
+			Group:         alert.Category,
+			Class:         alert.Action,
+			CustomDetails: details,
+		},
+	}
+
+	for _, link := range alert.Links {
+		event.Links = append(event.Links, Link{
+			Href: link.URL,
+			Text: link.Text,
+		})
+	}
+
+	return event
+}
@@ -0,0 +1,159 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/retry"
+	"github.com/pkg/errors"
+)
+
+var alert = notify.Alert{
+	ID:           "MDE2OkF1ZGl0RW50cnkx",
+	Action:       "repo.destroy",
+	Category:     "repo",
+	Severity:     "critical",
+	Organisation: "ons",
+	Text:         "User *alice* deleted repo *ons/app*.",
+	CreatedAt:    "2020-06-01T12:00:00Z",
+	Fields:       []notify.Field{{Title: "Actor", Value: "User *alice*"}},
+	Links:        []notify.Link{{Text: "View Actor", URL: "https://github.com/alice"}},
+}
+
+// newTestNotifier returns a notifier sending events to a server responding to each request with the next of the passed statuses, and
+// a pointer to the events the server has received.
+func newTestNotifier(t *testing.T, statuses ...int) (*Notifier, *httptest.Server, *[]Event) {
+	var events []Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("unexpected error decoding event: %v", err)
+		}
+
+		status := statuses[len(statuses)-1]
+		if len(events) < len(statuses) {
+			status = statuses[len(events)]
+		}
+
+		events = append(events, event)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(status)
+		w.Write([]byte(`{"status":"success"}`))
+	}))
+
+	n := NewNotifier("routing-key")
+	n.eventsURL = server.URL
+	return n, server, &events
+}
+
+func TestNotifyTriggersIncident(t *testing.T) {
+	n, server, events := newTestNotifier(t, http.StatusAccepted)
+	defer server.Close()
+
+	if err := n.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := Event{
+		RoutingKey:  "routing-key",
+		EventAction: "trigger",
+		DedupKey:    "github-auditor/MDE2OkF1ZGl0RW50cnkx",
+		Client:      "GitHub Auditor",
+		Payload: Payload{
+			Summary:   "User alice deleted repo ons/app.",
+			Source:    "ons",
+			Severity:  "critical",
+			Timestamp: "2020-06-01T12:00:00Z",
+			Component: "github",
+			Group:     "repo",
+			Class:     "repo.destroy",
+			CustomDetails: map[string]string{
+				"action":    "repo.destroy",
+				"createdAt": "2020-06-01T12:00:00Z",
+				"Actor":     "User alice",
+			},
+		},
+		Links: []Link{{Href: "https://github.com/alice", Text: "View Actor"}},
+	}
+
+	if len(*events) != 1 || !reflect.DeepEqual((*events)[0], want) {
+		t.Errorf("events = %+v, want %+v", *events, want)
+	}
+}
+
+func TestNewEvent(t *testing.T) {
+	n := NewNotifier("routing-key")
+
+	tests := []struct {
+		severity string
+		want     string
+	}{
+		{severity: "info", want: "info"},
+		{severity: "warning", want: "warning"},
+		{severity: "critical", want: "critical"},
+	}
+
+	for _, test := range tests {
+		a := alert
+		a.Severity = test.severity
+		if got := n.NewEvent(a).Payload.Severity; got != test.want {
+			t.Errorf("%s alert: severity = %q, want %q", test.severity, got, test.want)
+		}
+	}
+
+	// The source falls back to GitHub when the organisation isn't known, and long text is truncated to the summary's limit.
+	a := alert
+	a.Organisation = ""
+	a.Text = strings.Repeat("a", 2000)
+	event := n.NewEvent(a)
+
+	if event.Payload.Source != defaultSource {
+		t.Errorf("source = %q, want %q", event.Payload.Source, defaultSource)
+	}
+
+	if length := len([]rune(event.Payload.Summary)); length != maxSummaryLength {
+		t.Errorf("summary is %d characters, want %d", length, maxSummaryLength)
+	}
+
+	// Alerts for the same event have the same dedup key and alerts for different events don't.
+	other := alert
+	other.ID = "MDE2OkF1ZGl0RW50cnky"
+	if n.NewEvent(a).DedupKey != n.NewEvent(alert).DedupKey || n.NewEvent(other).DedupKey == n.NewEvent(alert).DedupKey {
+		t.Error("dedup keys aren't derived from the event IDs")
+	}
+}
+
+func TestNotifyReturnsStatusError(t *testing.T) {
+	n, server, events := newTestNotifier(t, http.StatusBadRequest)
+	defer server.Close()
+
+	err := n.Notify(context.Background(), alert)
+
+	statusErr, ok := errors.Cause(err).(*retry.StatusError)
+	if !ok || statusErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("error = %v, want a 400 StatusError", err)
+	}
+
+	if len(*events) != 1 {
+		t.Errorf("received %d events, want 1", len(*events))
+	}
+}
+
+func TestNotifyRetriesRateLimitedRequests(t *testing.T) {
+	n, server, events := newTestNotifier(t, http.StatusTooManyRequests, http.StatusAccepted)
+	defer server.Close()
+
+	if err := n.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*events) != 2 || (*events)[0].DedupKey != (*events)[1].DedupKey {
+		t.Errorf("events = %+v, want the same event twice", *events)
+	}
+}
@@ -0,0 +1,116 @@
+package retry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// StatusError is returned when an HTTP endpoint responds with an error status.
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+// Error returns a description of the HTTP error status.
+func (e *StatusError) Error() string {
+	if len(e.Body) > 0 {
+		return fmt.Sprintf("HTTP error %s: %s", e.Status, e.Body)
+	}
+
+	return fmt.Sprintf("HTTP error %s", e.Status)
+}
+
+// PostJSON POSTs the passed JSON body to the passed URL with the passed additional headers using the passed client, or
+// http.DefaultClient if nil, and returns the response body. Rate limited (429) responses are retried after the delay given by the
+// Retry-After header, and server errors, timeouts and network failures are retried using Backoff, up to MaxAttempts times in all.
+// Other errors sending the request, such as a redirect refused by the client, are returned without retrying.
+func PostJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body []byte) ([]byte, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+
+		var delay time.Duration
+		resp, err := client.Do(req.WithContext(ctx))
+
+		switch {
+		case err != nil:
+			if ctx.Err() != nil || attempt == MaxAttempts || permanent(err) {
+				return nil, err
+			}
+
+			delay = Backoff(attempt)
+			log.Printf("Request to %s failed: %v, retrying in %v (attempt %d of %d)", req.URL.Host, err, delay, attempt, MaxAttempts)
+
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+			statusErr := newStatusError(resp)
+			if attempt == MaxAttempts {
+				return nil, statusErr
+			}
+
+			delay = Backoff(attempt)
+			if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+				delay = time.Duration(seconds) * time.Second
+			}
+
+			log.Printf("Request to %s failed with status %s, retrying in %v (attempt %d of %d)", req.URL.Host, resp.Status, delay, attempt, MaxAttempts)
+
+		case resp.StatusCode >= 400:
+			return nil, newStatusError(resp)
+
+		default:
+			defer resp.Body.Close()
+			return ioutil.ReadAll(resp.Body)
+		}
+
+		if err := Wait(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// permanent returns whether the passed error sending a request can't be fixed by retrying the request, such as a redirect refused by the
+// client's CheckRedirect function (which for a webhook means its URL is wrong or has been revoked) or an invalid certificate. Timeouts
+// and network failures, such as a refused connection, aren't permanent.
+func permanent(err error) bool {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) || urlErr.Timeout() {
+		return false
+	}
+
+	var netErr net.Error
+	return !errors.As(urlErr.Err, &netErr)
+}
+
+// newStatusError returns a StatusError for the passed response, including the start of the response body to help diagnose the error.
+func newStatusError(resp *http.Response) *StatusError {
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(&io.LimitedReader{R: resp.Body, N: 512})
+
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       string(bytes.TrimSpace(body)),
+	}
+}
@@ -0,0 +1,169 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+var headers = map[string]string{"Authorization": "Bearer token"}
+
+// newTestServer returns a server responding to each request with the next of the passed statuses, with the passed Retry-After header
+// on rate limited responses, and a pointer to the number of requests received.
+func newTestServer(t *testing.T, retryAfter string, statuses ...int) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Authorization header = %q", got)
+		}
+
+		status := statuses[len(statuses)-1]
+		if requests < len(statuses) {
+			status = statuses[requests]
+		}
+
+		requests++
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+
+		w.WriteHeader(status)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+
+	return server, &requests
+}
+
+func TestPostJSONRetriesRateLimitedRequests(t *testing.T) {
+	server, requests := newTestServer(t, "0", http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK)
+	defer server.Close()
+
+	body, err := PostJSON(context.Background(), nil, server.URL, headers, []byte(`{"text":"hello"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(body) != `{"ok":true}` {
+		t.Errorf("body = %s", body)
+	}
+
+	if *requests != 3 {
+		t.Errorf("made %d requests, want 3", *requests)
+	}
+}
+
+func TestPostJSONHonoursRetryAfter(t *testing.T) {
+	server, requests := newTestServer(t, "1", http.StatusTooManyRequests, http.StatusOK)
+	defer server.Close()
+
+	start := time.Now()
+	if _, err := PostJSON(context.Background(), nil, server.URL, headers, []byte(`{"text":"hello"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want at least the 1s Retry-After delay", elapsed)
+	}
+
+	if *requests != 2 {
+		t.Errorf("made %d requests, want 2", *requests)
+	}
+}
+
+func TestPostJSONStopsWaitingWhenCancelled(t *testing.T) {
+	server, requests := newTestServer(t, "60", http.StatusTooManyRequests)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := PostJSON(ctx, nil, server.URL, headers, []byte(`{"text":"hello"}`)); err != context.DeadlineExceeded {
+		t.Errorf("error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if *requests != 1 {
+		t.Errorf("made %d requests, want 1", *requests)
+	}
+}
+
+func TestPostJSONGivesUpAfterMaxAttempts(t *testing.T) {
+	server, requests := newTestServer(t, "0", http.StatusTooManyRequests)
+	defer server.Close()
+
+	_, err := PostJSON(context.Background(), nil, server.URL, headers, []byte(`{"text":"hello"}`))
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("error = %v, want a 429 StatusError", err)
+	}
+
+	if *requests != MaxAttempts {
+		t.Errorf("made %d requests, want %d", *requests, MaxAttempts)
+	}
+}
+
+func TestPostJSONDoesNotRetryClientErrors(t *testing.T) {
+	server, requests := newTestServer(t, "0", http.StatusBadRequest)
+	defer server.Close()
+
+	_, err := PostJSON(context.Background(), nil, server.URL, headers, []byte(`{"text":"hello"}`))
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("error = %v, want a 400 StatusError", err)
+	}
+
+	if *requests != 1 {
+		t.Errorf("made %d requests, want 1", *requests)
+	}
+}
+
+func TestPostJSONDoesNotRetryRefusedRedirects(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Redirect(w, r, "/login", http.StatusFound)
+	}))
+
+	defer server.Close()
+
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return errors.New("redirect refused")
+		},
+	}
+
+	if _, err := PostJSON(context.Background(), client, server.URL, headers, []byte(`{"text":"hello"}`)); err == nil {
+		t.Error("expected an error for a refused redirect")
+	}
+
+	if requests != 1 {
+		t.Errorf("made %d requests, want 1", requests)
+	}
+}
+
+func TestPermanent(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "refused redirect", err: &url.Error{Op: "Post", URL: "https://example.com", Err: errors.New("redirect refused")}, want: true},
+		{name: "refused connection", err: &url.Error{Op: "Post", URL: "https://example.com", Err: refused}},
+		{name: "timeout", err: &url.Error{Op: "Post", URL: "https://example.com", Err: context.DeadlineExceeded}},
+		{name: "other error", err: errors.New("failed")},
+	}
+
+	for _, test := range tests {
+		if got := permanent(test.err); got != test.want {
+			t.Errorf("%s: permanent = %t, want %t", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Package retry retries requests to external services that fail with transient errors.
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+const (
+
+	// MaxAttempts is the number of times a request is attempted before giving up.
+	MaxAttempts = 5
+
+	baseDelay = 1 * time.Second
+	maxDelay  = 30 * time.Second
+)
+
+// Backoff returns a random delay of up to a second doubled for each attempt, capped at 30 seconds ("full jitter").
+func Backoff(attempt int) time.Duration {
+	delay := baseDelay << uint(attempt-1)
+	if delay > maxDelay || delay <= 0 {
+		delay = maxDelay
+	}
+
+	return time.Duration(rand.Int63n(int64(delay)))
+}
+
+// Wait waits for the passed delay, returning the context's error if it is done first.
+func Wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
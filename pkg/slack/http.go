@@ -0,0 +1,34 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ONSdigital/github-auditor/pkg/retry"
+)
+
+var httpClient = &http.Client{
+	CheckRedirect: redirectPolicyFunc,
+}
+
+func redirectPolicyFunc(req *http.Request, via []*http.Request) error {
+	return fmt.Errorf("Incorrect token (redirection)")
+}
+
+// postJSON POSTs the passed payload as JSON to the passed URL, adding the passed bearer token if it isn't empty, and returns the
+// response body. Failed requests are retried by retry.PostJSON, which returns a *retry.StatusError for an HTTP error status.
+func postJSON(ctx context.Context, url, token string, payload interface{}) ([]byte, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var headers map[string]string
+	if len(token) > 0 {
+		headers = map[string]string{"Authorization": "Bearer " + token}
+	}
+
+	return retry.PostJSON(ctx, httpClient, url, headers, body)
+}
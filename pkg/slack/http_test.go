@@ -0,0 +1,54 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPostJSONSendsPayloadWithToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Authorization header = %q", got)
+		}
+
+		var payload Payload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Text != "hello" {
+			t.Errorf("payload = %+v, error = %v", payload, err)
+		}
+
+		w.Write([]byte(`{"ok":true}`))
+	}))
+
+	defer server.Close()
+
+	body, err := postJSON(context.Background(), server.URL, "token", Payload{Text: "hello"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(body) != `{"ok":true}` {
+		t.Errorf("body = %s", body)
+	}
+}
+
+func TestPostJSONRefusesRedirects(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Redirect(w, r, "/login", http.StatusFound)
+	}))
+
+	defer server.Close()
+
+	if _, err := postJSON(context.Background(), server.URL, "token", Payload{Text: "hello"}); err == nil {
+		t.Error("expected an error for a redirected request")
+	}
+
+	// A redirect means the token or webhook URL is wrong, so the request isn't retried.
+	if requests != 1 {
+		t.Errorf("made %d requests, want 1", requests)
+	}
+}
@@ -0,0 +1,95 @@
+package slack
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+
+	// Slack allows one message per second to each incoming webhook and each channel using chat.postMessage, with short bursts over
+	// this limit allowed. See https://api.slack.com/docs/rate-limits.
+	messagesPerSecond = 1
+	messageBurst      = 3
+)
+
+// tokenBucket is a token bucket rate limiter. Tokens are added at a fixed rate up to a maximum burst size, and each message consumes one.
+type tokenBucket struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(perSecond float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		interval: time.Duration(float64(time.Second) / perSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// Wait blocks until a token is available and consumes it, returning an error if the passed context is cancelled first.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		delay := b.take()
+		if delay == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// take consumes a token if one is available, otherwise it returns how long until one will be.
+func (b *tokenBucket) take() time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := time.Now()
+	b.tokens += float64(now.Sub(b.last)) / float64(b.interval)
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - b.tokens) * float64(b.interval))
+}
+
+// rateLimiters holds a token bucket for each key, such as a channel name.
+type rateLimiters struct {
+	mutex   sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// Wait blocks until a message can be sent for the passed key.
+func (l *rateLimiters) Wait(ctx context.Context, key string) error {
+	l.mutex.Lock()
+	if l.buckets == nil {
+		l.buckets = make(map[string]*tokenBucket)
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = newTokenBucket(messagesPerSecond, messageBurst)
+		l.buckets[key] = bucket
+	}
+	l.mutex.Unlock()
+
+	return bucket.Wait(ctx)
+}
@@ -0,0 +1,84 @@
+package slack
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketAllowsBurst(t *testing.T) {
+	b := newTokenBucket(1, 3)
+	for i := 1; i <= 3; i++ {
+		if delay := b.take(); delay != 0 {
+			t.Fatalf("message %d of the burst delayed by %v", i, delay)
+		}
+	}
+
+	delay := b.take()
+	if delay <= 0 || delay > time.Second {
+		t.Errorf("message after the burst delayed by %v, want up to 1s", delay)
+	}
+}
+
+func TestTokenBucketRefills(t *testing.T) {
+	b := newTokenBucket(1, 2)
+	b.take()
+	b.take()
+
+	// Long after the last message the bucket is full again, but holds no more than its burst.
+	b.last = b.last.Add(-10 * time.Second)
+	for i := 1; i <= 2; i++ {
+		if delay := b.take(); delay != 0 {
+			t.Fatalf("message %d after refilling delayed by %v", i, delay)
+		}
+	}
+
+	if delay := b.take(); delay == 0 {
+		t.Error("bucket refilled beyond its burst")
+	}
+}
+
+func TestTokenBucketWait(t *testing.T) {
+	b := newTokenBucket(50, 1)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := b.Wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The first message is sent immediately and the next two 20ms apart.
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("three messages at 50 per second took %v, want about 40ms", elapsed)
+	}
+}
+
+func TestTokenBucketWaitCancelled(t *testing.T) {
+	b := newTokenBucket(0.01, 1)
+	b.take()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := b.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Wait returned %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRateLimitersArePerKey(t *testing.T) {
+	var limiters rateLimiters
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Each key has its own burst, so a full burst to one channel doesn't delay another.
+	start := time.Now()
+	for _, key := range []string{"a", "a", "a", "b", "b", "b"} {
+		if err := limiters.Wait(ctx, key); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("bursts to two keys took %v", elapsed)
+	}
+}
@@ -0,0 +1,39 @@
+package slack
+
+import "context"
+
+type (
+
+	// Transport is implemented by the ways of posting messages to Slack.
+	Transport interface {
+
+		// Post posts the passed payload, returning the timestamp (ts) identifying the posted message if the transport supports it.
+		// The timestamp can be used as the ThreadTS of later payloads to post them as replies in a thread.
+		Post(ctx context.Context, payload Payload) (string, error)
+	}
+
+	// WebhookTransport posts messages using a Slack incoming webhook. Incoming webhooks don't return the timestamp of posted messages,
+	// so messages can't be threaded, and modern webhooks always post to the channel they were created for.
+	WebhookTransport struct {
+		webHookURL string
+		limiter    *tokenBucket
+	}
+)
+
+// NewWebhookTransport instantiates a transport posting messages to the passed Slack incoming webhook URL.
+func NewWebhookTransport(webHookURL string) *WebhookTransport {
+	return &WebhookTransport{
+		webHookURL: webHookURL,
+		limiter:    newTokenBucket(messagesPerSecond, messageBurst),
+	}
+}
+
+// Post posts the passed payload to the incoming webhook, waiting if necessary to stay within Slack's rate limits. The returned
+// timestamp is always empty.
+func (t *WebhookTransport) Post(ctx context.Context, payload Payload) (string, error) {
+	if err := t.limiter.Wait(ctx); err != nil {
+		return "", err
+	}
+
+	return "", Send(ctx, t.webHookURL, payload)
+}
@@ -0,0 +1,76 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+type (
+
+	// WebAPITransport posts messages using the Slack Web API chat.postMessage method, authenticated using a bot token.
+	// Unlike incoming webhooks, messages can be posted to any channel the bot is a member of and can be threaded.
+	WebAPITransport struct {
+		token    string
+		baseURL  string
+		limiters rateLimiters
+	}
+
+	// APIError is returned when the Slack Web API responds with "ok": false.
+	APIError struct {
+		Method string
+		Code   string
+	}
+
+	postMessageResponse struct {
+		OK      bool   `json:"ok"`
+		Error   string `json:"error"`
+		Channel string `json:"channel"`
+		TS      string `json:"ts"`
+	}
+)
+
+const webAPIBaseURL = "https://slack.com/api"
+
+// NewWebAPITransport instantiates a transport posting messages using the Slack Web API with the passed bot token.
+func NewWebAPITransport(token string) *WebAPITransport {
+	return &WebAPITransport{
+		token:   token,
+		baseURL: webAPIBaseURL,
+	}
+}
+
+// Error returns a description of the Slack Web API error.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Slack API method %s failed: %s", e.Method, e.Code)
+}
+
+// Post posts the passed payload using chat.postMessage, waiting if necessary to stay within Slack's rate limit for the channel, and
+// returns the timestamp of the posted message. The payload's Channel must be set to the name or ID of the channel to post to.
+func (t *WebAPITransport) Post(ctx context.Context, payload Payload) (string, error) {
+	if len(payload.Channel) == 0 {
+		return "", errors.New("a channel is required to post a message using the Slack Web API")
+	}
+
+	if err := t.limiters.Wait(ctx, payload.Channel); err != nil {
+		return "", err
+	}
+
+	body, err := postJSON(ctx, t.baseURL+"/chat.postMessage", t.token, payload)
+	if err != nil {
+		return "", err
+	}
+
+	var res postMessageResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return "", errors.Wrap(err, "failed to decode chat.postMessage response")
+	}
+
+	if !res.OK {
+		return "", &APIError{Method: "chat.postMessage", Code: res.Error}
+	}
+
+	return res.TS, nil
+}
@@ -0,0 +1,128 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newWebAPIServer returns a stand-in for the Slack Web API that responds to chat.postMessage with the passed response, or with a new
+// message timestamp if it is empty, and a pointer to the payloads posted to it.
+func newWebAPIServer(t *testing.T, response string) (*httptest.Server, *[]map[string]interface{}) {
+	var payloads []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat.postMessage" {
+			t.Errorf("path = %s, want /chat.postMessage", r.URL.Path)
+		}
+
+		if got := r.Header.Get("Authorization"); got != "Bearer xoxb-token" {
+			t.Errorf("Authorization header = %q", got)
+		}
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+
+		payloads = append(payloads, payload)
+		body := response
+		if len(body) == 0 {
+			body = fmt.Sprintf(`{"ok":true,"channel":"C123","ts":"1591012800.00010%d"}`, len(payloads))
+		}
+
+		w.Write([]byte(body))
+	}))
+
+	t.Cleanup(server.Close)
+	return server, &payloads
+}
+
+func newTestWebAPITransport(baseURL string) *WebAPITransport {
+	transport := NewWebAPITransport("xoxb-token")
+	transport.baseURL = baseURL
+	return transport
+}
+
+func TestWebAPITransportPostsMessage(t *testing.T) {
+	server, payloads := newWebAPIServer(t, "")
+	transport := newTestWebAPITransport(server.URL)
+
+	ts, err := transport.Post(context.Background(), Payload{Text: "hello", Channel: "#alerts"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ts != "1591012800.000101" {
+		t.Errorf("timestamp = %q, want %q", ts, "1591012800.000101")
+	}
+
+	if len(*payloads) != 1 {
+		t.Fatalf("posted %d messages, want 1", len(*payloads))
+	}
+
+	payload := (*payloads)[0]
+	if payload["text"] != "hello" || payload["channel"] != "#alerts" {
+		t.Errorf("payload = %v", payload)
+	}
+
+	if _, ok := payload["thread_ts"]; ok {
+		t.Errorf("payload of a message that isn't a reply includes thread_ts: %v", payload)
+	}
+}
+
+func TestWebAPITransportThreadsReplies(t *testing.T) {
+	server, payloads := newWebAPIServer(t, "")
+	transport := newTestWebAPITransport(server.URL)
+
+	ts, err := transport.Post(context.Background(), Payload{Text: "summary", Channel: "#alerts"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := transport.Post(context.Background(), Payload{Text: "details", Channel: "#alerts", ThreadTS: ts}); err != nil {
+		t.Fatalf("unexpected error posting reply: %v", err)
+	}
+
+	if len(*payloads) != 2 {
+		t.Fatalf("posted %d messages, want 2", len(*payloads))
+	}
+
+	if reply := (*payloads)[1]; reply["thread_ts"] != ts || reply["text"] != "details" {
+		t.Errorf("reply payload = %v, want thread_ts %q", reply, ts)
+	}
+}
+
+func TestWebAPITransportReturnsAPIErrors(t *testing.T) {
+	server, _ := newWebAPIServer(t, `{"ok":false,"error":"channel_not_found"}`)
+	transport := newTestWebAPITransport(server.URL)
+
+	ts, err := transport.Post(context.Background(), Payload{Text: "hello", Channel: "#missing"})
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("error = %v, want an *APIError", err)
+	}
+
+	if apiErr.Method != "chat.postMessage" || apiErr.Code != "channel_not_found" {
+		t.Errorf("error = %+v", apiErr)
+	}
+
+	if len(ts) > 0 {
+		t.Errorf("timestamp = %q, want empty", ts)
+	}
+}
+
+func TestWebAPITransportRequiresChannel(t *testing.T) {
+	server, payloads := newWebAPIServer(t, "")
+	transport := newTestWebAPITransport(server.URL)
+
+	if _, err := transport.Post(context.Background(), Payload{Text: "hello"}); err == nil {
+		t.Error("expected an error posting without a channel")
+	}
+
+	if len(*payloads) > 0 {
+		t.Errorf("posted %d messages, want none", len(*payloads))
+	}
+}
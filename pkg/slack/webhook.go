@@ -1,46 +1,44 @@
 package slack
 
 import (
-	"fmt"
-
-	"github.com/parnurzeal/gorequest"
+	"context"
 )
 
 // Field represents a Slack message field.
 type Field struct {
-	Title string `json:"title"`
-	Value string `json:"value"`
-	Short bool   `json:"short"`
+	Title string `json:"title,omitempty"`
+	Value string `json:"value,omitempty"`
+	Short bool   `json:"short,omitempty"`
 }
 
 // Action represents a Slack message action.
 type Action struct {
-	Type  string `json:"type"`
-	Text  string `json:"text"`
-	URL   string `json:"url"`
-	Style string `json:"style"`
+	Type  string `json:"type,omitempty"`
+	Text  string `json:"text,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Style string `json:"style,omitempty"`
 }
 
 // Attachment represents a Slack message attachment.
 type Attachment struct {
-	Fallback     *string   `json:"fallback"`
-	Color        *string   `json:"color"`
-	PreText      *string   `json:"pretext"`
-	AuthorName   *string   `json:"author_name"`
-	AuthorLink   *string   `json:"author_link"`
-	AuthorIcon   *string   `json:"author_icon"`
-	Title        *string   `json:"title"`
-	TitleLink    *string   `json:"title_link"`
-	Text         *string   `json:"text"`
-	ImageURL     *string   `json:"image_url"`
-	Fields       []*Field  `json:"fields"`
-	Footer       *string   `json:"footer"`
-	FooterIcon   *string   `json:"footer_icon"`
-	Timestamp    *int64    `json:"ts"`
-	MarkdownIn   *[]string `json:"mrkdwn_in"`
-	Actions      []*Action `json:"actions"`
-	CallbackID   *string   `json:"callback_id"`
-	ThumbnailURL *string   `json:"thumb_url"`
+	Fallback     *string   `json:"fallback,omitempty"`
+	Color        *string   `json:"color,omitempty"`
+	PreText      *string   `json:"pretext,omitempty"`
+	AuthorName   *string   `json:"author_name,omitempty"`
+	AuthorLink   *string   `json:"author_link,omitempty"`
+	AuthorIcon   *string   `json:"author_icon,omitempty"`
+	Title        *string   `json:"title,omitempty"`
+	TitleLink    *string   `json:"title_link,omitempty"`
+	Text         *string   `json:"text,omitempty"`
+	ImageURL     *string   `json:"image_url,omitempty"`
+	Fields       []*Field  `json:"fields,omitempty"`
+	Footer       *string   `json:"footer,omitempty"`
+	FooterIcon   *string   `json:"footer_icon,omitempty"`
+	Timestamp    *int64    `json:"ts,omitempty"`
+	MarkdownIn   *[]string `json:"mrkdwn_in,omitempty"`
+	Actions      []*Action `json:"actions,omitempty"`
+	CallbackID   *string   `json:"callback_id,omitempty"`
+	ThumbnailURL *string   `json:"thumb_url,omitempty"`
 }
 
 // Payload represents a Slack message payload.
@@ -50,6 +48,7 @@ type Payload struct {
 	IconURL     string       `json:"icon_url,omitempty"`
 	IconEmoji   string       `json:"icon_emoji,omitempty"`
 	Channel     string       `json:"channel,omitempty"`
+	ThreadTS    string       `json:"thread_ts,omitempty"` // Only supported by the Web API.
 	Text        string       `json:"text,omitempty"`
 	LinkNames   string       `json:"link_names,omitempty"`
 	Attachments []Attachment `json:"attachments,omitempty"`
@@ -70,21 +69,8 @@ func (attachment *Attachment) AddField(field Field) *Attachment {
 	return attachment
 }
 
-func redirectPolicyFunc(req gorequest.Request, via []gorequest.Request) error {
-	return fmt.Errorf("Incorrect token (redirection)")
-}
-
-// Send POSTS the passed payload to the passed Slack webhook URL.
-func Send(webHookURL string, payload Payload) []error {
-	request := gorequest.New()
-	resp, _, err := request.Post(webHookURL).RedirectPolicy(redirectPolicyFunc).Send(payload).End()
-
-	if err != nil {
-		return err
-	}
-	if resp.StatusCode >= 400 {
-		return []error{fmt.Errorf("Error sending message: %v", resp.Status)}
-	}
-
-	return nil
+// Send POSTS the passed payload to the passed Slack webhook URL. Rate limited requests and server errors are retried.
+func Send(ctx context.Context, webHookURL string, payload Payload) error {
+	_, err := postJSON(ctx, webHookURL, "", payload)
+	return err
 }
@@ -0,0 +1,108 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+type (
+
+	// FileStore is a Store that persists its state to a local JSON file, for running the auditor without a GCP project.
+	FileStore struct {
+		*MemoryStore
+		path       string
+		writeMutex sync.Mutex
+	}
+
+	fileContents struct {
+		Docs           map[string]Doc    `json:"docs"`
+		HighWaterMarks map[string]string `json:"highWaterMarks"`
+	}
+)
+
+// NewFileStore instantiates a new store backed by the JSON file at the passed path, loading any existing state from it.
+// The file is created when state is first saved if it doesn't already exist.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{
+		MemoryStore: NewMemoryStore(),
+		path:        path,
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read state file %s", path)
+	}
+
+	var contents fileContents
+	if err := json.Unmarshal(data, &contents); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse state file %s", path)
+	}
+
+	for id, doc := range contents.Docs {
+		doc.ID = id
+		s.docs[id] = doc
+	}
+
+	for organisation, createdAt := range contents.HighWaterMarks {
+		s.highWaterMarks[organisation] = createdAt
+	}
+
+	return s, nil
+}
+
+// SaveDocs creates or updates the passed documents, writing the state file once for the whole batch.
+func (s *FileStore) SaveDocs(ctx context.Context, docs []Doc) error {
+	s.MemoryStore.SaveDocs(ctx, docs)
+	return s.save()
+}
+
+// SaveHighWaterMark sets the high-water mark for the passed organisation to the passed createdAt timestamp.
+func (s *FileStore) SaveHighWaterMark(ctx context.Context, organisation, createdAt string) error {
+	s.MemoryStore.SaveHighWaterMark(ctx, organisation, createdAt)
+	return s.save()
+}
+
+// save writes the current state to a temporary file and renames it over the state file, so a crash mid-write can't corrupt it.
+func (s *FileStore) save() error {
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	s.mutex.RLock()
+	data, err := json.MarshalIndent(fileContents{
+		Docs:           s.docs,
+		HighWaterMarks: s.highWaterMarks,
+	}, "", "  ")
+	s.mutex.RUnlock()
+
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal state to JSON")
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary state file for %s", s.path)
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return errors.Wrapf(err, "failed to write temporary state file %s", tmp.Name())
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrapf(err, "failed to close temporary state file %s", tmp.Name())
+	}
+
+	return errors.Wrapf(os.Rename(tmp.Name(), s.path), "failed to replace state file %s", s.path)
+}
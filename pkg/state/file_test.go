@@ -0,0 +1,132 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// newTempDir returns a temporary directory that is removed when the test finishes.
+func newTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "state")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestNewFileStoreStartsEmptyWithoutFile(t *testing.T) {
+	path := filepath.Join(newTempDir(t), "state.json")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mark, _ := store.HighWaterMark(context.Background(), "ons"); len(mark) > 0 {
+		t.Errorf("HighWaterMark() = %q, want empty", mark)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("state file exists before any state was saved: %v", err)
+	}
+}
+
+func TestNewFileStoreRejectsInvalidFile(t *testing.T) {
+	path := filepath.Join(newTempDir(t), "state.json")
+	if err := ioutil.WriteFile(path, []byte("{"), 0600); err != nil {
+		t.Fatalf("failed to write state file: %v", err)
+	}
+
+	if _, err := NewFileStore(path); err == nil {
+		t.Error("expected an error for an invalid state file")
+	}
+}
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := newTempDir(t)
+	path := filepath.Join(dir, "state.json")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	docs := []Doc{
+		{ID: "1", Timestamp: "2020-06-01T12:00:00.000Z", Action: "repo.create"},
+		{ID: "1-slack", Timestamp: "2020-06-01T12:00:00.000Z", Action: "repo.create"},
+	}
+
+	if err := store.SaveDocs(ctx, docs); err != nil {
+		t.Fatalf("unexpected error saving docs: %v", err)
+	}
+
+	if err := store.SaveHighWaterMark(ctx, "ons", "2020-06-01T12:00:00.000Z"); err != nil {
+		t.Fatalf("unexpected error saving high-water mark: %v", err)
+	}
+
+	// The state file is written to a temporary file that's renamed over it, so none should be left behind.
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read directory: %v", err)
+	}
+
+	if len(files) != 1 || files[0].Name() != "state.json" {
+		var names []string
+		for _, f := range files {
+			names = append(names, f.Name())
+		}
+
+		t.Errorf("directory contains %v, want only state.json", names)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read state file: %v", err)
+	}
+
+	var contents fileContents
+	if err := json.Unmarshal(data, &contents); err != nil {
+		t.Fatalf("state file isn't valid JSON: %v", err)
+	}
+
+	reloaded, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error reloading store: %v", err)
+	}
+
+	exists, err := reloaded.DocsExist(ctx, append(docs, Doc{ID: "2", Timestamp: "2020-06-01T12:00:00.000Z", Action: "repo.create"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []bool{true, true, false}; !reflect.DeepEqual(exists, want) {
+		t.Errorf("DocsExist() after reloading = %v, want %v", exists, want)
+	}
+
+	if mark, _ := reloaded.HighWaterMark(ctx, "ons"); mark != "2020-06-01T12:00:00.000Z" {
+		t.Errorf("HighWaterMark() after reloading = %q, want %q", mark, "2020-06-01T12:00:00.000Z")
+	}
+}
+
+func TestFileStoreReplacesExistingFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(newTempDir(t), "state.json")
+	store, _ := NewFileStore(path)
+	store.SaveHighWaterMark(ctx, "ons", "2020-06-01T12:00:00.000Z")
+	store.SaveHighWaterMark(ctx, "ons", "2020-06-01T12:01:00.000Z")
+
+	reloaded, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error reloading store: %v", err)
+	}
+
+	if mark, _ := reloaded.HighWaterMark(ctx, "ons"); mark != "2020-06-01T12:01:00.000Z" {
+		t.Errorf("HighWaterMark() after reloading = %q, want %q", mark, "2020-06-01T12:01:00.000Z")
+	}
+}
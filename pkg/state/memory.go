@@ -0,0 +1,71 @@
+package state
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore is a Store that holds its state in memory. It is intended for tests and one-off runs where
+// duplicate alerts across runs don't matter.
+type MemoryStore struct {
+	mutex          sync.RWMutex
+	docs           map[string]Doc
+	highWaterMarks map[string]string
+}
+
+// NewMemoryStore instantiates a new, empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		docs:           make(map[string]Doc),
+		highWaterMarks: make(map[string]string),
+	}
+}
+
+// DocsExist returns, for each of the passed documents, whether a document with the same ID containing the same timestamp and action exists.
+func (s *MemoryStore) DocsExist(ctx context.Context, docs []Doc) ([]bool, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	exists := make([]bool, len(docs))
+	for i, doc := range docs {
+		existing, ok := s.docs[doc.ID]
+		exists[i] = ok && existing.Timestamp == doc.Timestamp && existing.Action == doc.Action
+	}
+
+	return exists, nil
+}
+
+// SaveDocs creates or updates the passed documents.
+func (s *MemoryStore) SaveDocs(ctx context.Context, docs []Doc) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, doc := range docs {
+		s.docs[doc.ID] = doc
+	}
+
+	return nil
+}
+
+// HighWaterMark returns the createdAt timestamp of the newest audit log event processed for the passed organisation.
+// An empty string is returned if no high-water mark has been saved yet.
+func (s *MemoryStore) HighWaterMark(ctx context.Context, organisation string) (string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.highWaterMarks[organisation], nil
+}
+
+// SaveHighWaterMark sets the high-water mark for the passed organisation to the passed createdAt timestamp.
+func (s *MemoryStore) SaveHighWaterMark(ctx context.Context, organisation, createdAt string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.highWaterMarks[organisation] = createdAt
+	return nil
+}
+
+// Close is a no-op for the in-memory store.
+func (s *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,50 @@
+package state
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestMemoryStoreDocsExist(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	saved := Doc{ID: "1", Timestamp: "2020-06-01T12:00:00.000Z", Action: "repo.create"}
+	if err := store.SaveDocs(ctx, []Doc{saved}); err != nil {
+		t.Fatalf("unexpected error saving docs: %v", err)
+	}
+
+	docs := []Doc{
+		saved,
+		{ID: "2", Timestamp: saved.Timestamp, Action: saved.Action},
+		{ID: "1", Timestamp: "2020-06-01T12:01:00.000Z", Action: saved.Action},
+		{ID: "1", Timestamp: saved.Timestamp, Action: "repo.destroy"},
+	}
+
+	exists, err := store.DocsExist(ctx, docs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []bool{true, false, false, false}; !reflect.DeepEqual(exists, want) {
+		t.Errorf("DocsExist() = %v, want %v", exists, want)
+	}
+}
+
+func TestMemoryStoreHighWaterMark(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	if mark, err := store.HighWaterMark(ctx, "ons"); err != nil || len(mark) > 0 {
+		t.Errorf("HighWaterMark() before saving = %q, %v, want empty", mark, err)
+	}
+
+	store.SaveHighWaterMark(ctx, "ons", "2020-06-01T12:00:00.000Z")
+	if mark, _ := store.HighWaterMark(ctx, "ons"); mark != "2020-06-01T12:00:00.000Z" {
+		t.Errorf("HighWaterMark() = %q, want %q", mark, "2020-06-01T12:00:00.000Z")
+	}
+
+	if mark, _ := store.HighWaterMark(ctx, "other"); len(mark) > 0 {
+		t.Errorf("HighWaterMark() for another organisation = %q, want empty", mark)
+	}
+}
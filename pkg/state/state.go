@@ -0,0 +1,31 @@
+package state
+
+import "context"
+
+// Doc represents a processed audit log event.
+type Doc struct {
+	ID        string `json:"-"`
+	Timestamp string `json:"timestamp"`
+	Action    string `json:"action"`
+}
+
+// Store is implemented by types that persist the state the auditor needs between runs: the events that have already been
+// processed (used to ensure duplicate alerts aren't created) and the audit log high-water mark for each organisation.
+type Store interface {
+
+	// DocsExist returns, for each of the passed documents, whether a document with the same ID containing the same timestamp and action exists.
+	DocsExist(ctx context.Context, docs []Doc) ([]bool, error)
+
+	// SaveDocs creates or updates the passed documents.
+	SaveDocs(ctx context.Context, docs []Doc) error
+
+	// HighWaterMark returns the createdAt timestamp of the newest audit log event processed for the passed organisation.
+	// An empty string is returned if no high-water mark has been saved yet.
+	HighWaterMark(ctx context.Context, organisation string) (string, error)
+
+	// SaveHighWaterMark sets the high-water mark for the passed organisation to the passed createdAt timestamp.
+	SaveHighWaterMark(ctx context.Context, organisation, createdAt string) error
+
+	// Close releases any resources held by the store.
+	Close() error
+}
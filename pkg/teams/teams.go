@@ -0,0 +1,150 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/retry"
+	"github.com/pkg/errors"
+)
+
+const (
+	adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	adaptiveCardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+	adaptiveCardVersion     = "1.4"
+)
+
+// severityColours maps each severity to the colour of the Adaptive Card title.
+var severityColours = map[string]string{
+	"info":     "Accent",
+	"warning":  "Warning",
+	"critical": "Attention",
+}
+
+type (
+
+	// Element is an Adaptive Card body element. Only the properties of the TextBlock and FactSet elements are supported.
+	Element struct {
+		Type   string `json:"type"`
+		Text   string `json:"text,omitempty"`
+		Weight string `json:"weight,omitempty"`
+		Size   string `json:"size,omitempty"`
+		Color  string `json:"color,omitempty"`
+		Wrap   bool   `json:"wrap,omitempty"`
+		Facts  []Fact `json:"facts,omitempty"`
+	}
+
+	// Fact is a titled value within an Adaptive Card FactSet element.
+	Fact struct {
+		Title string `json:"title"`
+		Value string `json:"value"`
+	}
+
+	// Action is an Adaptive Card action. Only the Action.OpenUrl action is supported.
+	Action struct {
+		Type  string `json:"type"`
+		Title string `json:"title"`
+		URL   string `json:"url"`
+	}
+
+	// AdaptiveCard represents an Adaptive Card.
+	AdaptiveCard struct {
+		Schema  string    `json:"$schema"`
+		Type    string    `json:"type"`
+		Version string    `json:"version"`
+		Body    []Element `json:"body"`
+		Actions []Action  `json:"actions,omitempty"`
+	}
+
+	// Attachment represents a card attached to a Teams message.
+	Attachment struct {
+		ContentType string       `json:"contentType"`
+		Content     AdaptiveCard `json:"content"`
+	}
+
+	// Message represents a Teams incoming webhook message.
+	Message struct {
+		Type        string       `json:"type"`
+		Attachments []Attachment `json:"attachments"`
+	}
+
+	// Notifier sends alerts to a Microsoft Teams channel using an incoming webhook, rendered as Adaptive Cards.
+	Notifier struct {
+		webHookURL string
+	}
+)
+
+// NewNotifier instantiates a notifier posting alerts to the passed Teams incoming webhook URL.
+func NewNotifier(webHookURL string) *Notifier {
+	return &Notifier{webHookURL: webHookURL}
+}
+
+// Notify posts the passed alert to the incoming webhook.
+func (n *Notifier) Notify(ctx context.Context, alert notify.Alert) error {
+	body, err := json.Marshal(NewMessage(alert))
+	if err != nil {
+		return errors.Wrap(err, "failed marshalling Teams message to JSON")
+	}
+
+	_, err = retry.PostJSON(ctx, nil, n.webHookURL, nil, body)
+	return errors.Wrap(err, "failed to send Teams message")
+}
+
+// NewMessage returns a Teams message for the passed alert, rendered as an Adaptive Card with a title coloured by severity, a fact for
+// each of the alert's fields and a button for each of its links.
+func NewMessage(alert notify.Alert) Message {
+	card := AdaptiveCard{
+		Schema:  adaptiveCardSchema,
+		Type:    "AdaptiveCard",
+		Version: adaptiveCardVersion,
+		Body: []Element{
+			{
+				Type:   "TextBlock",
+				Text:   alert.Title(),
+				Weight: "Bolder",
+				Size:   "Medium",
+				Color:  severityColours[alert.Severity],
+				Wrap:   true,
+			},
+			{
+				Type: "TextBlock",
+				Text: notify.Markdown(alert.Text),
+				Wrap: true,
+			},
+		},
+	}
+
+	if len(alert.Fields) > 0 {
+		facts := make([]Fact, len(alert.Fields))
+		for i, field := range alert.Fields {
+			facts[i] = Fact{
+				Title: field.Title,
+				Value: notify.PlainText(field.Value),
+			}
+		}
+
+		card.Body = append(card.Body, Element{
+			Type:  "FactSet",
+			Facts: facts,
+		})
+	}
+
+	for _, link := range alert.Links {
+		card.Actions = append(card.Actions, Action{
+			Type:  "Action.OpenUrl",
+			Title: link.Text,
+			URL:   link.URL,
+		})
+	}
+
+	return Message{
+		Type: "message",
+		Attachments: []Attachment{
+			{
+				ContentType: adaptiveCardContentType,
+				Content:     card,
+			},
+		},
+	}
+}
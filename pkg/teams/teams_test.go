@@ -0,0 +1,109 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/retry"
+	"github.com/pkg/errors"
+)
+
+var alert = notify.Alert{
+	ID:           "1",
+	Action:       "repo.destroy",
+	Severity:     "critical",
+	Organisation: "ons",
+	Text:         "User *alice* deleted repo *ons/app*.",
+	Fields:       []notify.Field{{Title: "Actor", Value: "User *alice*"}},
+	Links:        []notify.Link{{Text: "View Actor", URL: "https://github.com/alice"}},
+}
+
+// newTestServer returns a server responding to each request with the next of the passed statuses, and a pointer to the messages it
+// has received.
+func newTestServer(t *testing.T, statuses ...int) (*httptest.Server, *[]Message) {
+	var messages []Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message Message
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("unexpected error decoding message: %v", err)
+		}
+
+		status := statuses[len(statuses)-1]
+		if len(messages) < len(statuses) {
+			status = statuses[len(messages)]
+		}
+
+		messages = append(messages, message)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(status)
+	}))
+
+	return server, &messages
+}
+
+func TestNotifyPostsAdaptiveCard(t *testing.T) {
+	server, messages := newTestServer(t, http.StatusOK)
+	defer server.Close()
+
+	if err := NewNotifier(server.URL).Notify(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*messages) != 1 {
+		t.Fatalf("received %d messages, want 1", len(*messages))
+	}
+
+	message := (*messages)[0]
+	if message.Type != "message" || len(message.Attachments) != 1 || message.Attachments[0].ContentType != adaptiveCardContentType {
+		t.Fatalf("message = %+v, want a single Adaptive Card attachment", message)
+	}
+
+	card := message.Attachments[0].Content
+	want := []Element{
+		{Type: "TextBlock", Text: "GitHub audit log: ons repo.destroy (critical)", Weight: "Bolder", Size: "Medium", Color: "Attention", Wrap: true},
+		{Type: "TextBlock", Text: "User **alice** deleted repo **ons/app**.", Wrap: true},
+		{Type: "FactSet", Facts: []Fact{{Title: "Actor", Value: "User alice"}}},
+	}
+
+	if !reflect.DeepEqual(card.Body, want) {
+		t.Errorf("card body = %+v, want %+v", card.Body, want)
+	}
+
+	if actions := []Action{{Type: "Action.OpenUrl", Title: "View Actor", URL: "https://github.com/alice"}}; !reflect.DeepEqual(card.Actions, actions) {
+		t.Errorf("card actions = %+v, want %+v", card.Actions, actions)
+	}
+}
+
+func TestNotifyReturnsStatusError(t *testing.T) {
+	server, messages := newTestServer(t, http.StatusBadRequest)
+	defer server.Close()
+
+	err := NewNotifier(server.URL).Notify(context.Background(), alert)
+
+	statusErr, ok := errors.Cause(err).(*retry.StatusError)
+	if !ok || statusErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("error = %v, want a 400 StatusError", err)
+	}
+
+	if len(*messages) != 1 {
+		t.Errorf("received %d messages, want 1", len(*messages))
+	}
+}
+
+func TestNotifyRetriesServerErrors(t *testing.T) {
+	server, messages := newTestServer(t, http.StatusServiceUnavailable, http.StatusOK)
+	defer server.Close()
+
+	if err := NewNotifier(server.URL).Notify(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*messages) != 2 {
+		t.Errorf("received %d messages, want 2", len(*messages))
+	}
+}
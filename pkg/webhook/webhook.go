@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"text/template"
+
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/retry"
+	"github.com/pkg/errors"
+)
+
+// templateFuncs are the functions available to body templates in addition to the text/template built-ins.
+var templateFuncs = template.FuncMap{
+	"json":     toJSON,
+	"markdown": notify.Markdown,
+	"plain":    notify.PlainText,
+}
+
+// Notifier POSTs alerts as JSON to a generic webhook. The body is the alert itself unless a body template is set.
+type Notifier struct {
+	url      string
+	template *template.Template
+}
+
+// NewNotifier instantiates a notifier POSTing alerts to the passed URL. If the passed body template isn't empty it is used to render the
+// request body, with the alert as its data, e.g. {"text": {{ plain .Text | json }}}. The json function quotes a value as JSON and
+// the markdown and plain functions convert alert text to CommonMark and plain text respectively.
+func NewNotifier(url, bodyTemplate string) (*Notifier, error) {
+	n := &Notifier{url: url}
+	if len(bodyTemplate) == 0 {
+		return n, nil
+	}
+
+	t, err := template.New("body").Funcs(templateFuncs).Parse(bodyTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse webhook body template")
+	}
+
+	n.template = t
+	return n, nil
+}
+
+// NewNotifierFromFile instantiates a notifier POSTing alerts to the passed URL, with a request body rendered using the template in the
+// passed file. See NewNotifier.
+func NewNotifierFromFile(url, templateFile string) (*Notifier, error) {
+	data, err := ioutil.ReadFile(templateFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read webhook body template file %s", templateFile)
+	}
+
+	return NewNotifier(url, string(data))
+}
+
+// Notify POSTs the passed alert to the webhook.
+func (n *Notifier) Notify(ctx context.Context, alert notify.Alert) error {
+	body, err := n.render(alert)
+	if err != nil {
+		return err
+	}
+
+	_, err = retry.PostJSON(ctx, nil, n.url, nil, body)
+	return errors.Wrap(err, "failed to send webhook request")
+}
+
+// render returns the request body for the passed alert, checking that a templated body is valid JSON.
+func (n *Notifier) render(alert notify.Alert) ([]byte, error) {
+	if n.template == nil {
+		body, err := json.Marshal(alert)
+		return body, errors.Wrap(err, "failed marshalling alert to JSON")
+	}
+
+	var b bytes.Buffer
+	if err := n.template.Execute(&b, alert); err != nil {
+		return nil, errors.Wrap(err, "failed to render webhook body template")
+	}
+
+	if !json.Valid(b.Bytes()) {
+		return nil, errors.Errorf("webhook body template rendered invalid JSON: %s", b.String())
+	}
+
+	return b.Bytes(), nil
+}
+
+func toJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/github-auditor/pkg/notify"
+	"github.com/ONSdigital/github-auditor/pkg/retry"
+	"github.com/pkg/errors"
+)
+
+var alert = notify.Alert{
+	ID:       "1",
+	Action:   "repo.destroy",
+	Severity: "critical",
+	Text:     "User *alice* deleted repo *ons/app*.",
+	Events:   []json.RawMessage{json.RawMessage(`{"action":"repo.destroy"}`)},
+}
+
+// newTestServer returns a server responding to each request with the next of the passed statuses, and a pointer to the request
+// bodies it has received.
+func newTestServer(t *testing.T, statuses ...int) (*httptest.Server, *[]string) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Content-Type"); got != "application/json; charset=utf-8" {
+			t.Errorf("Content-Type header = %q", got)
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		status := statuses[len(statuses)-1]
+		if len(bodies) < len(statuses) {
+			status = statuses[len(bodies)]
+		}
+
+		bodies = append(bodies, string(body))
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(status)
+	}))
+
+	return server, &bodies
+}
+
+func TestNotifyPostsAlert(t *testing.T) {
+	server, bodies := newTestServer(t, http.StatusOK)
+	defer server.Close()
+
+	n, err := NewNotifier(server.URL, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := n.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*bodies) != 1 {
+		t.Fatalf("received %d requests, want 1", len(*bodies))
+	}
+
+	var got notify.Alert
+	if err := json.Unmarshal([]byte((*bodies)[0]), &got); err != nil {
+		t.Fatalf("unexpected error decoding body: %v", err)
+	}
+
+	if got.ID != alert.ID || got.Action != alert.Action || got.Text != alert.Text || len(got.Events) != 1 || string(got.Events[0]) != `{"action":"repo.destroy"}` {
+		t.Errorf("body = %s, want the alert", (*bodies)[0])
+	}
+}
+
+func TestNotifyRendersTemplate(t *testing.T) {
+	server, bodies := newTestServer(t, http.StatusOK)
+	defer server.Close()
+
+	n, err := NewNotifier(server.URL, `{"title": {{ .Title | json }}, "body": {{ plain .Text | json }}, "markdown": {{ markdown .Text | json }}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := n.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `{"title": "GitHub audit log: repo.destroy (critical)", "body": "User alice deleted repo ons/app.", "markdown": "User **alice** deleted repo **ons/app**."}`
+	if len(*bodies) != 1 || (*bodies)[0] != want {
+		t.Errorf("bodies = %q, want %q", *bodies, want)
+	}
+}
+
+func TestNotifyRejectsTemplateRenderingInvalidJSON(t *testing.T) {
+	server, bodies := newTestServer(t, http.StatusOK)
+	defer server.Close()
+
+	n, err := NewNotifier(server.URL, `{"text": {{ .Text }}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := n.Notify(context.Background(), alert); err == nil {
+		t.Error("expected an error for a template rendering invalid JSON")
+	}
+
+	if len(*bodies) != 0 {
+		t.Errorf("received %d requests, want none", len(*bodies))
+	}
+}
+
+func TestNewNotifierRejectsInvalidTemplate(t *testing.T) {
+	if _, err := NewNotifier("https://example.com", `{"text": {{ .Text }`); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+}
+
+func TestNotifyReturnsStatusError(t *testing.T) {
+	server, bodies := newTestServer(t, http.StatusUnauthorized)
+	defer server.Close()
+
+	n, _ := NewNotifier(server.URL, "")
+	err := n.Notify(context.Background(), alert)
+
+	statusErr, ok := errors.Cause(err).(*retry.StatusError)
+	if !ok || statusErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("error = %v, want a 401 StatusError", err)
+	}
+
+	if len(*bodies) != 1 {
+		t.Errorf("received %d requests, want 1", len(*bodies))
+	}
+}
+
+func TestNotifyRetriesServerErrors(t *testing.T) {
+	server, bodies := newTestServer(t, http.StatusBadGateway, http.StatusTooManyRequests, http.StatusOK)
+	defer server.Close()
+
+	n, _ := NewNotifier(server.URL, "")
+	if err := n.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*bodies) != 3 {
+		t.Errorf("received %d requests, want 3", len(*bodies))
+	}
+}